@@ -0,0 +1,322 @@
+package sqac
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/1414C/sqac/common"
+)
+
+// ColumnInfo describes a table column, either as reported by the
+// connected db, or as derived from the sqac tags of a model.  Type
+// and Default hold the db-specific type name and default expression.
+// Identity is set for auto-incrementing primary-key columns.  Enum
+// holds the permitted values of columns declared via an enum tag.
+type ColumnInfo struct {
+	Name       string
+	Type       string
+	Nullable   bool
+	Default    string
+	PrimaryKey bool
+	Identity   bool
+	Enum       []string `json:",omitempty"`
+}
+
+// DestructivePolicy determines how AlterTables deals with model changes
+// that would destroy data held in the db.  At the moment this covers
+// the dropping of columns whose fields have been removed from the model.
+// Type changes are passed through to the db, which is expected to refuse
+// conversions that cannot be carried out.
+type DestructivePolicy int
+
+const (
+	// DestructiveSkip logs destructive changes and leaves the db as-is.
+	// This is the default policy.
+	DestructiveSkip DestructivePolicy = iota
+
+	// DestructiveApply carries out destructive changes.
+	DestructiveApply
+
+	// DestructiveError causes AlterTables to return an error as soon as
+	// a destructive change is detected for a table.  Changes to the table
+	// in question are not applied.
+	DestructiveError
+)
+
+// String returns the name of the policy for logging purposes.
+func (p DestructivePolicy) String() string {
+
+	switch p {
+	case DestructiveSkip:
+		return "skip"
+	case DestructiveApply:
+		return "apply"
+	case DestructiveError:
+		return "error"
+	default:
+		return fmt.Sprintf("DestructivePolicy(%d)", int(p))
+	}
+}
+
+// colDelta describes a difference between a column in the db (from) and
+// the corresponding field in the model (to).  op is one of ALTER or DROP;
+// new columns continue to be handled directly by the flavor AlterTables
+// methods.
+type colDelta struct {
+	op      string
+	name    string
+	from    ColumnInfo
+	to      ColumnInfo
+	typeChg bool
+	nullChg bool
+	dfltChg bool
+}
+
+// destructive reports whether applying the delta could destroy data.
+func (d colDelta) destructive() bool {
+	return d.op == "DROP"
+}
+
+// SetDestructivePolicy sets the policy that AlterTables applies to
+// destructive changes such as the dropping of columns.
+func (bf *BaseFlavor) SetDestructivePolicy(p DestructivePolicy) {
+	bf.destructivePolicy = p
+}
+
+// GetDestructivePolicy reports the policy that AlterTables applies to
+// destructive changes.
+func (bf *BaseFlavor) GetDestructivePolicy() DestructivePolicy {
+	return bf.destructivePolicy
+}
+
+// modelColumns converts the column components captured by a flavor's
+// buildTablSchema method into ColumnInfo for comparison with the db.
+func (bf *BaseFlavor) modelColumns(tc TblComponents) []ColumnInfo {
+
+	pks := make(map[string]bool)
+	incs := make(map[string]bool)
+	for _, fd := range tc.flDef {
+		for _, p := range fd.SqacPairs {
+			if p.Name == "primary_key" {
+				pks[fd.FName] = true
+				incs[fd.FName] = p.Value == "inc"
+			}
+		}
+	}
+
+	cols := make([]ColumnInfo, 0, len(tc.cols))
+	for _, c := range tc.cols {
+		ci := ColumnInfo{
+			Name:       c.fName,
+			Type:       c.fType,
+			Nullable:   c.fNullable == "",
+			Default:    strings.TrimPrefix(c.fDefault, "DEFAULT "),
+			PrimaryKey: pks[c.fName],
+			Identity:   incs[c.fName],
+		}
+		if c.uType != "" {
+			ci.Type = c.uType
+		}
+		cols = append(cols, ci)
+	}
+	return cols
+}
+
+// diffColumns compares the model columns (want) with the columns found
+// in the db (have), and returns the list of required alterations.  Each
+// side is passed through the flavor-specific normalization function
+// before comparison, but the deltas carry the original values so that
+// the flavor may use the model's type and default verbatim.  Columns
+// that are missing in the db are not reported, and primary-key columns
+// are never altered.
+func (bf *BaseFlavor) diffColumns(want, have []ColumnInfo, norm func(ColumnInfo) ColumnInfo) []colDelta {
+
+	deltas := make([]colDelta, 0)
+	inModel := make(map[string]bool)
+
+	dbCols := make(map[string]ColumnInfo)
+	for _, h := range have {
+		dbCols[strings.ToLower(h.Name)] = h
+	}
+
+	for _, w := range want {
+		inModel[strings.ToLower(w.Name)] = true
+		h, ok := dbCols[strings.ToLower(w.Name)]
+		if !ok {
+			continue
+		}
+
+		if w.PrimaryKey || h.PrimaryKey {
+			continue
+		}
+
+		nw := norm(w)
+		nh := norm(h)
+		d := colDelta{
+			op:      "ALTER",
+			name:    w.Name,
+			from:    h,
+			to:      w,
+			typeChg: !strings.EqualFold(nw.Type, nh.Type),
+			nullChg: nw.Nullable != nh.Nullable,
+			dfltChg: !strings.EqualFold(nw.Default, nh.Default),
+		}
+		if d.typeChg || d.nullChg || d.dfltChg {
+			if bf.log {
+				log.Printf("column %s changed: type %s -> %s, nullable %v -> %v, default %s -> %s\n",
+					w.Name, h.Type, w.Type, h.Nullable, w.Nullable, h.Default, w.Default)
+			}
+			deltas = append(deltas, d)
+		}
+	}
+
+	for _, h := range have {
+		if !inModel[strings.ToLower(h.Name)] {
+			deltas = append(deltas, colDelta{op: "DROP", name: h.Name, from: h})
+		}
+	}
+	return deltas
+}
+
+// applyDestructivePolicy filters the deltas for table tn according to
+// the destructive-change policy of the handle.
+func (bf *BaseFlavor) applyDestructivePolicy(tn string, deltas []colDelta) ([]colDelta, error) {
+
+	rd := make([]colDelta, 0, len(deltas))
+	for _, d := range deltas {
+		if !d.destructive() {
+			rd = append(rd, d)
+			continue
+		}
+
+		switch bf.destructivePolicy {
+		case DestructiveApply:
+			rd = append(rd, d)
+
+		case DestructiveError:
+			return nil, fmt.Errorf("destructive change detected on table %s: %s column %s", tn, strings.ToLower(d.op), d.name)
+
+		default:
+			log.Printf("WARNING: skipping destructive change on table %s: %s column %s\n", tn, strings.ToLower(d.op), d.name)
+		}
+	}
+	return rd, nil
+}
+
+// columnRenamer is implemented by the flavors in order to rename columns
+// as per the renamed_from tags of a model.
+type columnRenamer interface {
+	renameColumnSQL(tn, from, to string) string
+	ExistsColumn(tn string, cn string) bool
+}
+
+// renameColumns renames the columns of table tn whose fields are tagged
+// with sqac:"renamed_from:<old_name>", provided that the old column exists
+// and the new column does not.  The renamed columns are returned so that
+// they are not added to the table as new columns.
+func (bf *BaseFlavor) renameColumns(r columnRenamer, tn string, tc TblComponents) (map[string]bool, error) {
+
+	renamed := make(map[string]bool)
+	for _, fd := range tc.flDef {
+		if fd.NoDB {
+			continue
+		}
+		for _, p := range fd.SqacPairs {
+			if p.Name != "renamed_from" || p.Value == "" || strings.EqualFold(p.Value, fd.FName) {
+				continue
+			}
+			if r.ExistsColumn(tn, fd.FName) || !r.ExistsColumn(tn, p.Value) {
+				continue
+			}
+			if bf.log {
+				log.Printf("renaming column %s of table %s to %s\n", p.Value, tn, fd.FName)
+			}
+			_, err := bf.Exec(r.renameColumnSQL(tn, p.Value, fd.FName))
+			if err != nil {
+				return nil, err
+			}
+			bf.renameInPlan(tn, p.Value, fd.FName)
+			renamed[fd.FName] = true
+		}
+	}
+	return renamed, nil
+}
+
+// tableRenamer is implemented by the flavors in order to rename tables as
+// per the renamed_from hints of the models.
+type tableRenamer interface {
+	renameTableSQL(from, to string) ([]string, error)
+	ExistsTable(tn string) bool
+}
+
+// renameTable renames the table of model ent to tn if the model carries a
+// struct-level renamed_from hint (_ struct{} `sqac:"renamed_from:<old_name>"`),
+// provided that the old table exists and table tn does not.  The old table
+// is looked for in the schema of table tn, and is routed to the tenant of
+// the handle like tn.  It reports whether the table was renamed.
+func (bf *BaseFlavor) renameTable(r tableRenamer, tn string, ent interface{}) (bool, error) {
+
+	from := common.GetTableRenamedFrom(ent)
+	if from == "" {
+		return false, nil
+	}
+	from = qualifiedName(tn, bf.tenantTableName(from))
+	if strings.EqualFold(from, tn) {
+		return false, nil
+	}
+	if r.ExistsTable(tn) || !r.ExistsTable(from) {
+		return false, nil
+	}
+	if bf.log {
+		log.Printf("renaming table %s to %s\n", from, tn)
+	}
+	stmts, err := r.renameTableSQL(from, tn)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range stmts {
+		_, err = bf.Exec(s)
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// trimDefault strips enclosing parentheses and quotes from a default
+// expression so that model and db representations can be compared.
+func trimDefault(s string) string {
+
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "DEFAULT "))
+	for {
+		switch {
+		case len(s) > 1 && strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") && balanced(s[1:len(s)-1]):
+			s = strings.TrimSpace(s[1 : len(s)-1])
+
+		case len(s) > 1 && strings.HasPrefix(s, "'") && strings.HasSuffix(s, "'"):
+			return s[1 : len(s)-1]
+
+		default:
+			return s
+		}
+	}
+}
+
+// balanced reports whether the parentheses in s are balanced.
+func balanced(s string) bool {
+
+	n := 0
+	for _, r := range s {
+		switch r {
+		case '(':
+			n++
+		case ')':
+			n--
+			if n < 0 {
+				return false
+			}
+		}
+	}
+	return n == 0
+}
@@ -0,0 +1,248 @@
+package sqac
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/1414C/sqac/common"
+	"github.com/jmoiron/sqlx/reflectx"
+	"github.com/lib/pq"
+)
+
+// ArrayElem lists the element types supported by Array.
+type ArrayElem interface {
+	string | int | int64 | int32 | float64 | float32 | bool
+}
+
+// Array holds a slice of scalars stored as a native array on postgres
+// (text[], bigint[] etc.), and as a json document on the other dbs.
+//
+//	type Cask struct {
+//		ID   uint64             `db:"id" sqac:"primary_key:inc"`
+//		Tags sqac.Array[string] `db:"tags" sqac:"nullable:true"`
+//	}
+//
+// Array columns may be filtered via the ANY (contains element) and @>
+// (contains all elements) operands of GetParam.  A nil Array is stored
+// as NULL.  Plain slices of the element types of Array ([]string, []int64
+// etc.) are stored in the same way.
+type Array[T ArrayElem] []T
+
+// ArrayColumn marks Array as an array column type for the TagReader.
+func (a Array[T]) ArrayColumn() {}
+
+// Scan implements sql.Scanner for both postgres array literals and json
+// documents.
+func (a *Array[T]) Scan(src interface{}) error {
+	return scanArray(reflect.ValueOf((*[]T)(a)).Elem(), src)
+}
+
+// scanArray scans src, a postgres array literal or a json document, into
+// slice v.
+func scanArray(v reflect.Value, src interface{}) error {
+
+	var b []byte
+	switch s := src.(type) {
+	case nil:
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	case string:
+		b = []byte(s)
+	case []byte:
+		b = s
+	default:
+		return fmt.Errorf("unable to scan %T into an array", src)
+	}
+
+	p := v.Addr().Interface()
+	if strings.HasPrefix(string(b), "{") {
+		return pq.Array(p).Scan(b)
+	}
+	return json.Unmarshal(b, p)
+}
+
+// sliceScanner scans a column into a plain slice field such as []string.
+type sliceScanner struct {
+	v reflect.Value
+}
+
+// Scan implements sql.Scanner.
+func (s sliceScanner) Scan(src interface{}) error {
+	return scanArray(s.v, src)
+}
+
+// rowScanner is implemented by sqlx.Row and sqlx.Rows.
+type rowScanner interface {
+	Columns() ([]string, error)
+	Scan(dest ...interface{}) error
+	StructScan(dest interface{}) error
+}
+
+// structScan scans the current row of r into the struct pointed to by dst,
+// as the StructScan of sqlx does.  sqlx is unable to scan into plain slices
+// of scalars, so the columns of such fields are scanned like Array columns.
+func (bf *BaseFlavor) structScan(r rowScanner, dst interface{}) error {
+
+	v := reflect.Indirect(reflect.ValueOf(dst))
+	if !hasScalarSlices(v.Type()) {
+		return r.StructScan(dst)
+	}
+
+	cols, err := r.Columns()
+	if err != nil {
+		return err
+	}
+	dests := make([]interface{}, len(cols))
+	for i, tr := range bf.db.Mapper.TraversalsByName(v.Type(), cols) {
+		if len(tr) == 0 {
+			return fmt.Errorf("missing destination name %s in %T", cols[i], dst)
+		}
+		f := reflectx.FieldByIndexes(v, tr)
+		if common.IsScalarSlice(f.Type()) {
+			dests[i] = sliceScanner{v: f}
+			continue
+		}
+		dests[i] = f.Addr().Interface()
+	}
+	return r.Scan(dests...)
+}
+
+// hasScalarSlices reports whether struct type t, or a struct embedded in
+// it, holds a plain slice of scalars.
+func hasScalarSlices(t reflect.Type) bool {
+
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if common.IsScalarSlice(f.Type) || (f.Anonymous && hasScalarSlices(f.Type)) {
+			return true
+		}
+	}
+	return false
+}
+
+// pgArrayTypes maps the element types of Array onto postgres types
+var pgArrayTypes = map[string]string{
+	"string":  "text",
+	"int":     "bigint",
+	"int64":   "bigint",
+	"int32":   "integer",
+	"float64": "double precision",
+	"float32": "real",
+	"bool":    "boolean",
+}
+
+// pgArrayType returns the postgres array type of array field fd;
+// sqac.Array[string] and []string map onto text[] for example.
+func pgArrayType(fd common.FieldDef) string {
+
+	et := strings.TrimPrefix(fd.GoType, "[]")
+	if et == fd.GoType {
+		et = fd.GoType[strings.LastIndex(fd.GoType, "[")+1:]
+		et = strings.TrimSuffix(et, "]")
+	}
+	return pgArrayTypes[et] + "[]"
+}
+
+// plainSlice converts slice v of a named slice type such as Array[string]
+// to its unnamed form ([]string).  Other values are returned as-is.
+func plainSlice(v interface{}) interface{} {
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.Type().Name() == "" {
+		return v
+	}
+	return rv.Convert(reflect.SliceOf(rv.Type().Elem())).Interface()
+}
+
+// arrayValue returns the bind value of slice v; a postgres array, or a
+// json document on the other dbs.
+func (bf *BaseFlavor) arrayValue(v interface{}) (interface{}, error) {
+
+	if bf.GetDBDriverName() == "postgres" {
+		return pq.Array(plainSlice(v)), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// paramPredicate returns the predicate of GetParam p along with its bind
+// values.  Operand ANY selects the rows whose array column holds the
+// ParamValue element, and operand @> the rows whose array column holds
+// all of the elements of the ParamValue slice.
+func (bf *BaseFlavor) paramPredicate(p GetParam) (string, []interface{}, error) {
+
+	cn, err := bf.paramColumn(p)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dn := bf.GetDBDriverName()
+	switch strings.ToUpper(p.Operand) {
+	case "ANY":
+		switch dn {
+		case "postgres":
+			return "? = ANY(" + cn + ")", []interface{}{p.ParamValue}, nil
+		case "mysql":
+			jv, err := json.Marshal(p.ParamValue)
+			if err != nil {
+				return "", nil, err
+			}
+			return "JSON_CONTAINS(" + cn + ", ?)", []interface{}{string(jv)}, nil
+		case "sqlite3":
+			return "EXISTS (SELECT 1 FROM json_each(" + cn + ") WHERE value = ?)", []interface{}{p.ParamValue}, nil
+		case "mssql":
+			return "EXISTS (SELECT 1 FROM OPENJSON(" + cn + ") WHERE value = ?)", []interface{}{p.ParamValue}, nil
+		}
+
+	case "@>":
+		av, err := bf.arrayValue(p.ParamValue)
+		if err != nil {
+			return "", nil, err
+		}
+		switch dn {
+		case "postgres":
+			return cn + " @> ?", []interface{}{av}, nil
+		case "mysql":
+			return "JSON_CONTAINS(" + cn + ", ?)", []interface{}{av}, nil
+		case "sqlite3":
+			return "NOT EXISTS (SELECT 1 FROM json_each(?) p WHERE p.value NOT IN (SELECT value FROM json_each(" + cn + ")))", []interface{}{av}, nil
+		case "mssql":
+			return "NOT EXISTS (SELECT 1 FROM OPENJSON(?) p WHERE p.value NOT IN (SELECT value FROM OPENJSON(" + cn + ")))", []interface{}{av}, nil
+		}
+
+	default:
+		return cn + " " + p.Operand + " ?", []interface{}{p.ParamValue}, nil
+	}
+	return "", nil, fmt.Errorf("operand %s is not supported for %s", p.Operand, dn)
+}
+
+// pgUDTArrayType returns the array type of postgres udt name udt, as
+// reported for array columns by the information_schema; _text maps onto
+// text[] for example.
+func pgUDTArrayType(udt string) string {
+
+	switch strings.TrimPrefix(udt, "_") {
+	case "text":
+		return "text[]"
+	case "int8":
+		return "bigint[]"
+	case "int4":
+		return "integer[]"
+	case "float8":
+		return "double precision[]"
+	case "float4":
+		return "real[]"
+	case "bool":
+		return "boolean[]"
+	default:
+		return strings.TrimPrefix(udt, "_") + "[]"
+	}
+}
@@ -1,6 +1,8 @@
 package sqac
 
 import (
+	"crypto/rand"
+	"database/sql/driver"
 	"fmt"
 	"log"
 	"reflect"
@@ -25,6 +27,7 @@ type CrudInfo struct {
 	fList      string
 	vList      string
 	fldMap     map[string]interface{} // string
+	args       []interface{}          // bind values of the fldMap placeholders
 	keyMap     map[string]interface{}
 	incKeyName string
 	entValue   reflect.Value
@@ -65,7 +68,12 @@ func (bf *BaseFlavor) BuildComponents(inf *CrudInfo) error {
 	}
 
 	// determine the table name as per the table creation logic
-	inf.tn = common.GetTableName(inf.ent)
+	inf.tn = bf.TableName(inf.ent)
+
+	// views are read-only
+	if inf.mode != "G" && common.IsView(inf.ent) {
+		return fmt.Errorf("%s is a view - views are read-only", inf.tn)
+	}
 
 	inf.fList = "("
 	inf.vList = "("
@@ -108,6 +116,7 @@ func (bf *BaseFlavor) BuildComponents(inf *CrudInfo) error {
 		bDefault := false
 		bPkeyInc := false
 		bPkey := false
+		bPkeyUUID := false
 		bIsNull := false
 
 		// set the field attribute indicators
@@ -119,6 +128,7 @@ func (bf *BaseFlavor) BuildComponents(inf *CrudInfo) error {
 					inf.incKeyName = fd.FName //MySQL :/
 				} else {
 					bPkey = true
+					bPkeyUUID = t.Value == "uuid"
 				}
 			case "default":
 				bDefault = true
@@ -253,6 +263,18 @@ func (bf *BaseFlavor) BuildComponents(inf *CrudInfo) error {
 					inf.fldMap[fd.FName] = "DEFAULT"
 					continue
 				}
+				if bPkeyUUID == true && !bIsNull {
+					// generate the key if the caller did not set one and
+					// retain it to read back the created row
+					if fvr.String() == "" {
+						id, err := newUUID()
+						if err != nil {
+							return err
+						}
+						fvr.SetString(id)
+					}
+					inf.keyMap[fd.FName] = fvr.String()
+				}
 				if bDefault == true && fv == "" ||
 					bDefault == true && bIsNull {
 					inf.fList = inf.fList + fd.FName + ", "
@@ -266,6 +288,11 @@ func (bf *BaseFlavor) BuildComponents(inf *CrudInfo) error {
 					continue
 				}
 			}
+			// values of enum fields are validated before they reach the db
+			if ev := fd.TagValue("enum"); ev != "" && !bIsNull && !inEnum(fvr.String(), ev) {
+				return fmt.Errorf("%s value %s is not one of %s", fd.FName, fvr.String(), ev)
+			}
+
 			// in all other cases, just use the given value making the
 			// assumption that the string-type field contains a string-type
 			inf.fList = inf.fList + fd.FName + ", "
@@ -349,13 +376,13 @@ func (bf *BaseFlavor) BuildComponents(inf *CrudInfo) error {
 				// deal with time keys, as they are immutable in update scenario
 				if bPkeyInc == true || bPkey == true {
 					// inf.keyMap[fd.FName] = fv.(time.Time).Format(time.RFC3339)
-					inf.keyMap[fd.FName] = bf.TimeToFormattedString(fv) // fv.(time.Time).Format("2006-01-02 15:04:05.999999-07:00")
+					inf.keyMap[fd.FName] = bf.TimeToFormattedString(bf.storeTime(fvr.Interface().(time.Time))) // fv.(time.Time).Format("2006-01-02 15:04:05.999999-07:00")
 					continue
 				}
 			}
 			inf.fList = inf.fList + fd.FName + ", "
 			if !bIsNull {
-				sv := bf.TimeToFormattedString(fv)
+				sv := bf.TimeToFormattedString(bf.storeTime(fvr.Interface().(time.Time)))
 				inf.vList = inf.vList + "'" + sv + "', "
 				inf.fldMap[fd.FName] = "'" + sv + "'"
 			} else {
@@ -364,6 +391,85 @@ func (bf *BaseFlavor) BuildComponents(inf *CrudInfo) error {
 			}
 			continue
 
+		case "[]byte":
+
+			// binary data is passed to the db as a bind value; a nil
+			// slice is stored as NULL and an empty slice as-is
+			if fvr.Kind() == reflect.Slice && fvr.IsNil() {
+				bIsNull = true
+			}
+			inf.fList = inf.fList + fd.FName + ", "
+			if !bIsNull {
+				inf.args = append(inf.args, fvr.Bytes())
+				bv := bf.bindVar(len(inf.args))
+				inf.vList = inf.vList + bv + ", "
+				inf.fldMap[fd.FName] = bv
+			} else {
+				inf.vList = inf.vList + "NULL, "
+				inf.fldMap[fd.FName] = "NULL"
+			}
+			continue
+
+		case common.ArrayType:
+
+			// arrays are passed as native arrays on postgres and as json
+			// documents elsewhere; a nil slice is stored as NULL
+			if fvr.Kind() == reflect.Slice && fvr.IsNil() {
+				bIsNull = true
+			}
+			inf.fList = inf.fList + fd.FName + ", "
+			if !bIsNull {
+				av, err := bf.arrayValue(fvr.Interface())
+				if err != nil {
+					return err
+				}
+				inf.args = append(inf.args, av)
+				bv := bf.bindVar(len(inf.args))
+				inf.vList = inf.vList + bv + ", "
+				inf.fldMap[fd.FName] = bv
+			} else {
+				inf.vList = inf.vList + "NULL, "
+				inf.fldMap[fd.FName] = "NULL"
+			}
+			continue
+
+		case common.ValuerType, common.JSONType, common.DecimalType, common.DateType, common.TimeOfDayType:
+
+			// the value of the field is passed to the db as a bind value
+			dv, err := fieldValue(inf.entValue.Field(i))
+			if err != nil {
+				return err
+			}
+			if dv == nil {
+				bIsNull = true
+			}
+
+			if inf.mode == "C" {
+				if bDefault == true && bIsNull ||
+					bDefault == true && reflect.DeepEqual(fv, reflect.Zero(reflect.TypeOf(fv)).Interface()) {
+					inf.fList = inf.fList + fd.FName + ", "
+					inf.vList = inf.vList + "DEFAULT, "
+					inf.fldMap[fd.FName] = "DEFAULT"
+					continue
+				}
+			} else {
+				if bPkey == true {
+					inf.keyMap[fd.FName] = dv
+					continue
+				}
+			}
+			inf.fList = inf.fList + fd.FName + ", "
+			if !bIsNull {
+				inf.args = append(inf.args, dv)
+				bv := bf.bindVar(len(inf.args))
+				inf.vList = inf.vList + bv + ", "
+				inf.fldMap[fd.FName] = bv
+			} else {
+				inf.vList = inf.vList + "NULL, "
+				inf.fldMap[fd.FName] = "NULL"
+			}
+			continue
+
 		default:
 			log.Printf("%s with go-type %s is unsupported\n", fd.FName, fd.GoType)
 			continue
@@ -378,9 +484,88 @@ func (bf *BaseFlavor) BuildComponents(inf *CrudInfo) error {
 
 }
 
+// fields returns the names of the fields held in fldMap in model order.
+// The bind values in args are numbered in model order, so statements built
+// from fldMap list the fields in this order.
+func (inf *CrudInfo) fields() []string {
+
+	fns := make([]string, 0, len(inf.fldMap))
+	for _, fd := range inf.flDef {
+		if _, ok := inf.fldMap[fd.FName]; ok {
+			fns = append(fns, fd.FName)
+		}
+	}
+	return fns
+}
+
+// keyCondition returns the condition selecting the row written by Create;
+// by the value lastID of the auto-incrementing key, or by the generated
+// uuid key values held in keyMap.
+func (inf *CrudInfo) keyCondition(lastID int64) string {
+
+	if inf.incKeyName != "" {
+		return inf.incKeyName + " = " + strconv.FormatInt(lastID, 10)
+	}
+	kc := ""
+	for _, fd := range inf.flDef {
+		if kv, ok := inf.keyMap[fd.FName]; ok {
+			kc = fmt.Sprintf("%s %s = '%v' AND", kc, fd.FName, kv)
+		}
+	}
+	return strings.TrimSpace(strings.TrimSuffix(kc, " AND"))
+}
+
+// newUUID returns a random (version 4) uuid in its canonical text form.
+func newUUID() (string, error) {
+
+	var u [16]byte
+	_, err := rand.Read(u[:])
+	if err != nil {
+		return "", err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
+
+// fieldValue returns the db value of field v, whose type implements
+// driver.Valuer on either its value or its pointer.  A nil pointer
+// yields a nil value.
+func fieldValue(v reflect.Value) (driver.Value, error) {
+
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+	if vlr, ok := v.Interface().(driver.Valuer); ok {
+		return vlr.Value()
+	}
+	if v.CanAddr() {
+		if vlr, ok := v.Addr().Interface().(driver.Valuer); ok {
+			return vlr.Value()
+		}
+	}
+	return nil, fmt.Errorf("go-type %s does not implement driver.Valuer", v.Type())
+}
+
+// bindVar returns the placeholder of the n'th (1-based) bind value of a
+// statement on the connected db.
+func (bf *BaseFlavor) bindVar(n int) string {
+
+	switch bf.GetDBDriverName() {
+	case "postgres":
+		return "$" + strconv.Itoa(n)
+	case "mssql":
+		return "@p" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
+
 // TimeToFormattedString is used to format the provided time.Time
 // or *time.Time value in the string format required for the
-// connected db insert or update operation.  This method is called
+// connected db insert or update operation.  Date and TimeOfDay
+// values are formatted as yyyy-mm-dd and hh:mm:ss[.fffffffff]
+// on all dbs.  This method is called
 // from within the CRUD ops for each db flavor, and could be added
 // to the flavor-specific Query / Exec methods at some point.
 func (bf *BaseFlavor) TimeToFormattedString(i interface{}) string {
@@ -392,6 +577,10 @@ func (bf *BaseFlavor) TimeToFormattedString(i interface{}) string {
 	}
 
 	switch i.(type) {
+	case Date:
+		return i.(Date).String()
+	case TimeOfDay:
+		return i.(TimeOfDay).String()
 	case time.Time:
 		t = i.(time.Time)
 	case *time.Time:
@@ -0,0 +1,143 @@
+package sqac
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/golang-sql/civil"
+)
+
+// Date holds a calendar date without a time of day or location.  Date
+// fields are stored in date columns, and a zero Date is stored as NULL.
+// Date converts to and from civil.Date.
+type Date civil.Date
+
+// TimeOfDay holds a time of day without a date or location.  TimeOfDay
+// fields are stored in time columns; use a *TimeOfDay field for a
+// nullable column, as the zero TimeOfDay is midnight.  TimeOfDay converts
+// to and from civil.Time.
+type TimeOfDay civil.Time
+
+// DateOf returns the date of time t in the location of t.
+func DateOf(t time.Time) Date {
+	return Date(civil.DateOf(t))
+}
+
+// TimeOfDayOf returns the time of day of time t in the location of t.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay(civil.TimeOf(t))
+}
+
+// DateColumn marks Date as a date column type for the TagReader.
+func (d Date) DateColumn() {}
+
+// String returns the date in yyyy-mm-dd format.
+func (d Date) String() string {
+	return civil.Date(d).String()
+}
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns the time of midnight at the start of date d in location loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return civil.Date(d).In(loc)
+}
+
+// Value implements driver.Valuer.
+func (d Date) Value() (driver.Value, error) {
+
+	if d.IsZero() {
+		return nil, nil
+	}
+	if !civil.Date(d).IsValid() {
+		return nil, fmt.Errorf("%s is not a valid date", d.String())
+	}
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner.  Most drivers return date values as
+// time.Time; some return them as text.
+func (d *Date) Scan(src interface{}) error {
+
+	switch v := src.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = DateOf(v)
+	case string:
+		return d.parse(v)
+	case []byte:
+		return d.parse(string(v))
+	default:
+		return fmt.Errorf("unable to scan %T into a date", src)
+	}
+	return nil
+}
+
+// parse sets d from the leading yyyy-mm-dd of s.
+func (d *Date) parse(s string) error {
+
+	if len(s) > 10 {
+		s = s[:10]
+	}
+	cd, err := civil.ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = Date(cd)
+	return nil
+}
+
+// TimeOfDayColumn marks TimeOfDay as a time column type for the TagReader.
+func (t TimeOfDay) TimeOfDayColumn() {}
+
+// String returns the time of day in hh:mm:ss[.fffffffff] format.
+func (t TimeOfDay) String() string {
+	return civil.Time(t).String()
+}
+
+// Value implements driver.Valuer.
+func (t TimeOfDay) Value() (driver.Value, error) {
+
+	if !civil.Time(t).IsValid() {
+		return nil, fmt.Errorf("%s is not a valid time of day", t.String())
+	}
+	return t.String(), nil
+}
+
+// Scan implements sql.Scanner.  The postgres, mysql and sqlite drivers
+// return time values as text, mssql and hdb as time.Time.
+func (t *TimeOfDay) Scan(src interface{}) error {
+
+	switch v := src.(type) {
+	case nil:
+		*t = TimeOfDay{}
+	case time.Time:
+		*t = TimeOfDayOf(v)
+	case string:
+		return t.parse(v)
+	case []byte:
+		return t.parse(string(v))
+	default:
+		return fmt.Errorf("unable to scan %T into a time of day", src)
+	}
+	return nil
+}
+
+// parse sets t from s, dropping any date in front of the time of day.
+func (t *TimeOfDay) parse(s string) error {
+
+	if len(s) > 10 && s[10] == ' ' || len(s) > 10 && s[10] == 'T' {
+		s = s[11:]
+	}
+	ct, err := civil.ParseTime(s)
+	if err != nil {
+		return err
+	}
+	*t = TimeOfDay(ct)
+	return nil
+}
@@ -0,0 +1,75 @@
+package sqac
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/1414C/sqac/common"
+)
+
+// Decimal holds an exact decimal number in its text form, such as
+// "1234.50".  Decimal fields are stored in decimal(p,s) columns, with
+// precision and scale set via the sqac:"precision:<p>;scale:<s>" tags
+// (default 18 and 2), and are passed to the db as text rather than
+// through float64.  An empty Decimal is stored as NULL.
+type Decimal string
+
+// decimalRegexp matches the text form of a decimal number
+var decimalRegexp = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+
+// DecimalColumn marks Decimal as a decimal column type for the TagReader.
+func (d Decimal) DecimalColumn() {}
+
+// Value implements driver.Valuer.
+func (d Decimal) Value() (driver.Value, error) {
+
+	if d == "" {
+		return nil, nil
+	}
+	if !decimalRegexp.MatchString(string(d)) {
+		return nil, fmt.Errorf("%s is not a decimal number", string(d))
+	}
+	return string(d), nil
+}
+
+// Scan implements sql.Scanner.  Most drivers return decimal values as
+// text; SQLite returns them as numbers.
+func (d *Decimal) Scan(src interface{}) error {
+
+	switch v := src.(type) {
+	case nil:
+		*d = ""
+	case string:
+		*d = Decimal(v)
+	case []byte:
+		*d = Decimal(v)
+	case int64:
+		*d = Decimal(strconv.FormatInt(v, 10))
+	case float64:
+		*d = Decimal(strconv.FormatFloat(v, 'f', -1, 64))
+	case *big.Rat:
+		*d = Decimal(strings.TrimRight(strings.TrimRight(v.FloatString(38), "0"), "."))
+	default:
+		return fmt.Errorf("unable to scan %T into a decimal", src)
+	}
+	return nil
+}
+
+// decimalType returns db type tn (decimal or numeric) with the precision
+// and scale given by the tags of field fd.
+func decimalType(tn string, fd common.FieldDef) string {
+
+	p := fd.TagValue("precision")
+	if p == "" {
+		p = "18"
+	}
+	s := fd.TagValue("scale")
+	if s == "" {
+		s = "2"
+	}
+	return tn + "(" + p + "," + s + ")"
+}
@@ -0,0 +1,122 @@
+package sqac
+
+import (
+	"regexp"
+	"strings"
+)
+
+// checkReader is implemented by the flavors that enforce enum tags via
+// check constraints, and are able to read the check constraints of
+// their tables.
+type checkReader interface {
+	readChecks(tn string) (map[string]string, error)
+}
+
+// ensure that the flavors using check constraints for enums are able
+// to read them back
+var (
+	_ checkReader = &PostgresFlavor{}
+	_ checkReader = &MySQLFlavor{}
+	_ checkReader = &SQLiteFlavor{}
+	_ checkReader = &MSSQLFlavor{}
+	_ checkReader = &HDBFlavor{}
+)
+
+// enumSuffix is appended to the names of the check constraints that
+// enforce enum tags, so that their values can be read back from the db.
+const enumSuffix = "_enum"
+
+// enumLiteralRegexp matches the quoted literals of a type or check
+// constraint definition
+var enumLiteralRegexp = regexp.MustCompile(`'((?:[^']|'')*)'`)
+
+// enumValues returns the values of enum tag value ev; a|b|c
+func enumValues(ev string) []string {
+	return strings.Split(ev, "|")
+}
+
+// enumList returns the values of enum tag value ev as a list of quoted
+// literals; 'a', 'b', 'c'
+func enumList(ev string) string {
+
+	vals := enumValues(ev)
+	for i, v := range vals {
+		vals[i] = "'" + strings.Replace(v, "'", "''", -1) + "'"
+	}
+	return strings.Join(vals, ", ")
+}
+
+// inEnum reports whether v is one of the values of enum tag value ev.
+func inEnum(v, ev string) bool {
+
+	for _, e := range enumValues(ev) {
+		if v == e {
+			return true
+		}
+	}
+	return false
+}
+
+// enumLiterals returns the quoted literals of type or check constraint
+// definition def in order of appearance.
+func enumLiterals(def string) []string {
+
+	vals := make([]string, 0)
+	for _, m := range enumLiteralRegexp.FindAllStringSubmatch(def, -1) {
+		vals = append(vals, strings.Replace(m[1], "''", "'", -1))
+	}
+	return vals
+}
+
+// processEnumTag adds a check constraint limiting the field to the values
+// of enum tag value ev to the working constraints map.
+func (bf *BaseFlavor) processEnumTag(cMap map[string]ConstraintInfo, tableName string, fieldName string,
+	ev string) map[string]ConstraintInfo {
+
+	con := ConstraintInfo{
+		TableName: tableName,
+		Type:      "CHECK",
+		Fields:    []string{fieldName},
+		Check:     fieldName + " IN (" + enumList(ev) + ")",
+	}
+	cMap[enumCheckName(tableName, fieldName)] = con
+	return cMap
+}
+
+// enumCheckName returns the name of the check constraint enforcing the
+// enum tag of column cn of table tn; ck_<tn>_<cn>_enum, where <tn> is
+// the table-name without its schema.
+func enumCheckName(tn, cn string) string {
+	return "ck_" + bareTableName(tn) + "_" + cn + enumSuffix
+}
+
+// readEnums sets the enum values of the columns of table tn; from the
+// enum types of the columns, or from the enum check constraints of the
+// table read via r.
+func (bf *BaseFlavor) readEnums(r schemaReader, tn string, cols []ColumnInfo) error {
+
+	for i, ci := range cols {
+		if strings.HasPrefix(strings.ToLower(ci.Type), "enum(") {
+			cols[i].Enum = enumLiterals(ci.Type)
+		}
+	}
+
+	cr, ok := r.(checkReader)
+	if !ok {
+		return nil
+	}
+	checks, err := cr.readChecks(tn)
+	if err != nil {
+		return err
+	}
+	defs := make(map[string]string)
+	for cn, def := range checks {
+		defs[strings.ToLower(cn)] = def
+	}
+	for i, ci := range cols {
+		if def, ok := defs[strings.ToLower(enumCheckName(tn, ci.Name))]; ok {
+			cols[i].Enum = enumLiterals(def)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package sqac
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// errOffline is returned for every attempt to access the db through an
+// offline handle.
+var errOffline = fmt.Errorf("sqac: the handle is offline and not connected to a db")
+
+// offlineConnector is the driver.Connector backing the sqlx.DB of an
+// offline handle.  It refuses all connections, but allows the handle to
+// report its driver name and to rebind queries for its flavor.
+type offlineConnector struct{}
+
+// Connect always fails with errOffline.
+func (offlineConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errOffline
+}
+
+// Driver returns the offline driver.
+func (offlineConnector) Driver() driver.Driver {
+	return offlineDriver{}
+}
+
+// offlineDriver is the driver.Driver of the offlineConnector.
+type offlineDriver struct{}
+
+// Open always fails with errOffline.
+func (offlineDriver) Open(string) (driver.Conn, error) {
+	return nil, errOffline
+}
+
+// offlineDB returns a sqlx.DB for driver dn that is not connected to a db.
+func offlineDB(dn string) *sqlx.DB {
+	return sqlx.NewDb(sql.OpenDB(offlineConnector{}), dn)
+}
+
+// ExportDDL returns a SQL script holding the statements that CreateTables
+// would execute for the models on an empty db of the specified flavor.
+// The script covers the CREATE TABLE, sequence, index and foreign-key
+// statements, and is rendered without a connection to a db.  The flavor
+// names are those accepted by Create.
+func ExportDDL(flavor string, i ...interface{}) (string, error) {
+
+	handle, err := CreateOffline(flavor, false)
+	if err != nil {
+		return "", err
+	}
+
+	stmts, err := handle.PlanCreateTables(i...)
+	if err != nil {
+		return "", err
+	}
+	return JoinStatements(stmts), nil
+}
+
+// JoinStatements joins the statements into a SQL script with one
+// statement per line, each terminated by a semicolon.  Empty statements
+// are skipped.
+func JoinStatements(stmts []string) string {
+
+	var sb strings.Builder
+	for _, s := range stmts {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		sb.WriteString(s)
+		if !strings.HasSuffix(s, ";") {
+			sb.WriteString(";")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
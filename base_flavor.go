@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/1414C/sqac/common"
@@ -28,6 +30,17 @@ type FKeyInfo struct {
 	FKeyName  string
 }
 
+// ConstraintInfo holds table-level constraint definitions as read from the
+// sqac:"constraint:<name>" and sqac:"check:<expr>" tags.  Grouped unique
+// constraints list their fields in the order in which they appear in the
+// model, much like multi-column indexes.
+type ConstraintInfo struct {
+	TableName string
+	Type      string // UNIQUE or CHECK
+	Fields    []string
+	Check     string
+}
+
 // ForeignKeyBuffer is used to hold deferred foreign-key information
 // pending the creation of all tables submitted in a CreateTable(...)
 // or AlterTable(...) call.
@@ -57,6 +70,8 @@ type TblComponents struct {
 	flDef     []common.FieldDef
 	seq       []common.SqacPair
 	ind       map[string]IndexInfo
+	cons      map[string]ConstraintInfo
+	cols      []ColComponents
 	fkey      []FKeyInfo
 	pk        string
 	err       error
@@ -68,6 +83,7 @@ type GetParam struct {
 	Operand      string
 	ParamValue   interface{}
 	NextOperator string
+	JSONPath     string // optional dot-separated path into a json column; dims.width
 }
 
 // Log dumps all of the raw table components to stdout is called for CreateTable
@@ -85,6 +101,10 @@ func (tc *TblComponents) Log() {
 		log.Printf("INDEX: k:%s	fields:%v  unique:%v tableName:%s\n", k, v.IndexFields, v.Unique, v.TableName)
 	}
 	log.Println("--")
+	for k, v := range tc.cons {
+		log.Printf("CONSTRAINT: k:%s	type:%s fields:%v check:%s tableName:%s\n", k, v.Type, v.Fields, v.Check, v.TableName)
+	}
+	log.Println("--")
 	log.Println("PRIMARY KEYS:", tc.pk)
 	log.Println("--")
 	for _, v := range tc.flDef {
@@ -148,6 +168,58 @@ type PublicDB interface {
 	DestructiveResetTables(i ...interface{}) error
 	ExistsTable(tn string) bool
 
+	// vn=viewName; views are created by CreateTables and AlterTables
+	// from models implementing common.ViewQueryer
+	ExistsView(vn string) bool
+	DropView(vn string) error
+
+	// set / get the schema of tables whose models do not name one, get
+	// the schema-qualified table name of a model, and create, drop or
+	// check for the existence of schema sn
+	SetSchema(sn string)
+	GetSchema() string
+	TableName(ent interface{}) string
+	CreateSchema(sn string) error
+	DropSchema(sn string) error
+	ExistsSchema(sn string) bool
+
+	// set / get the options deriving the table names of models that do
+	// not name their table explicitly
+	SetTableNaming(n common.TableNaming)
+	GetTableNaming() common.TableNaming
+
+	// return a handle routing the table names to the tables of tenant,
+	// set the hook doing the routing, and get the tenant of a handle
+	ForTenant(tenant string) PublicDB
+	SetTenantResolver(r TenantResolver)
+	GetTenant() string
+
+	// set / get the handling of destructive changes in AlterTables
+	SetDestructivePolicy(p DestructivePolicy)
+	GetDestructivePolicy() DestructivePolicy
+
+	// set / get the handling of time zones for time fields
+	SetTimePolicy(p TimePolicy)
+	GetTimePolicy() TimePolicy
+
+	// return the ordered list of statements that the corresponding
+	// table operation would execute, without making changes to the db
+	PlanCreateTables(i ...interface{}) ([]string, error)
+	PlanAlterTables(i ...interface{}) ([]string, error)
+	PlanDropTables(i ...interface{}) ([]string, error)
+	PlanDestructiveResetTables(i ...interface{}) ([]string, error)
+
+	// snapshot the tables described by the models, and return the
+	// statements required to migrate the tables from the db (from=nil),
+	// or from a previous snapshot, to the models and back again
+	SnapshotTables(i ...interface{}) (Snapshot, error)
+	PlanMigration(from *Snapshot, i ...interface{}) (up []string, down []string, err error)
+
+	// describe an existing table, or generate the source of sqac-tagged
+	// models for existing tables
+	DescribeTable(tn string) (TableInfo, error)
+	GenerateModels(pkg string, tn ...string) ([]byte, error)
+
 	// tn=tableName, cn=columnName
 	ExistsColumn(tn string, cn string) bool
 
@@ -156,6 +228,11 @@ type PublicDB interface {
 	DropIndex(tn string, in string) error
 	ExistsIndex(tn string, in string) bool
 
+	// tn=tableName, cn=constraintName
+	CreateConstraint(cn string, constraint ConstraintInfo) error
+	DropConstraint(tn string, cn string) error
+	ExistsConstraint(tn string, cn string) bool
+
 	// sn=sequenceName, start=start-value, name is used to hold
 	// the name of the sequence, autoincrement or identity
 	// field name.  the use of name depends on which db system
@@ -195,6 +272,10 @@ type PublicDB interface {
 	DBBoolToBool(interface{}) bool
 	TimeToFormattedString(i interface{}) string
 
+	// call stored procedure / function name, passing the fields of in as
+	// parameters and reading OUT parameters or result sets into out
+	CallProcedure(name string, in interface{}, out interface{}) error
+
 	// CRUD ops
 	Create(ent interface{}) error
 	Update(ent interface{}) error
@@ -212,9 +293,20 @@ var _ PublicDB = &BaseFlavor{}
 
 // BaseFlavor is a supporting struct for interface PublicDB
 type BaseFlavor struct {
-	db    *sqlx.DB
-	log   bool
-	dbLog bool
+	db                *sqlx.DB
+	log               bool
+	dbLog             bool
+	destructivePolicy DestructivePolicy
+	timePolicy        TimePolicy
+	planning          bool
+	plan              []string
+	planDrops         map[string]bool
+	planRenames       map[string]map[string]string
+	offline           bool
+	schema            string
+	tableNaming       common.TableNaming
+	tenant            string
+	tenantResolver    TenantResolver
 	PublicDB
 }
 
@@ -421,11 +513,18 @@ func (bf *BaseFlavor) CreateTables(i ...interface{}) error {
 // the provided list of go struct definitions.
 func (bf *BaseFlavor) DropTables(i ...interface{}) error {
 
+	// views are dropped ahead of the tables they select from
+	i, views := splitViews(i)
+	err := bf.dropViews(bf, views...)
+	if err != nil {
+		return err
+	}
+
 	dropSchema := ""
 	for t := range i {
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := bf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in bf.DropTables")
 		}
@@ -438,8 +537,9 @@ func (bf *BaseFlavor) DropTables(i ...interface{}) error {
 				log.Printf("table %s exists - adding to drop schema...\n", tn)
 			}
 			// submit 1 at a time for mysql
-			dropSchema = dropSchema + "DROP TABLE " + tn + ";"
+			dropSchema = dropSchema + bf.dropTableSQL(tn)
 			bf.ProcessSchema(dropSchema)
+			bf.dropInPlan(tn)
 			dropSchema = ""
 		}
 	}
@@ -468,7 +568,7 @@ func (bf *BaseFlavor) ExistsTable(tn string) bool {
 
 	n := 0
 	qs := "SELECT count(*) FROM INFORMATION_SCHEMA.TABLES WHERE table_schema = ? AND table_name = ?;"
-	dbName := bf.GetDBName()
+	dbName, tn := bf.splitSchema(tn)
 
 	bf.QsLog(qs, dbName)
 	bf.db.QueryRow(qs, dbName, tn).Scan(&n)
@@ -486,9 +586,9 @@ func (bf *BaseFlavor) ExistsColumn(tn string, cn string) bool {
 
 	n := 0
 	qs := "SELECT COUNT(*) FROM information_schema.COLUMNS WHERE table_schema = ? AND table_name = ? AND column_name = ?;"
-	dbName := bf.GetDBName()
 
 	if bf.ExistsTable(tn) {
+		dbName, tn := bf.splitSchema(tn)
 		bf.QsLog(qs, dbName, tn, cn)
 		bf.db.QueryRow(qs, dbName, tn, cn).Scan(&n)
 		if n > 0 {
@@ -511,7 +611,7 @@ func (bf *BaseFlavor) CreateIndex(in string, index IndexInfo) error {
 
 	if len(index.IndexFields) == 1 {
 		fList = index.IndexFields[0]
-		in = "idx_" + index.TableName + "_" + fList
+		in = "idx_" + bareTableName(index.TableName) + "_" + fList
 	} else {
 		for _, f := range index.IndexFields {
 			fList = fList + f + ", "
@@ -541,7 +641,7 @@ func (bf *BaseFlavor) ExistsIndex(tn string, in string) bool {
 
 	n := 0
 	qs := "SELECT count(*) FROM INFORMATION_SCHEMA.STATISTICS WHERE table_schema = ? AND table_name = ? AND index_name = ?"
-	dbName := bf.GetDBName()
+	dbName, tn := bf.splitSchema(tn)
 
 	bf.QsLog(qs, dbName, tn, in)
 	bf.db.QueryRow(qs, dbName, tn, in).Scan(&n)
@@ -551,6 +651,52 @@ func (bf *BaseFlavor) ExistsIndex(tn string, in string) bool {
 	return false
 }
 
+// CreateConstraint adds the unique or check constraint contained in the
+// incoming ConstraintInfo structure to an existing table.  Grouped unique
+// constraint fields are added in the order they are contained in the
+// ConstraintInfo.[]Fields slice.
+func (bf *BaseFlavor) CreateConstraint(cn string, constraint ConstraintInfo) error {
+
+	// ALTER TABLE depot ADD CONSTRAINT uq_region_province UNIQUE (region, province);
+	schema := "ALTER TABLE " + constraint.TableName + " ADD " + bf.constraintClause(cn, constraint) + ";"
+	bf.QsLog(schema)
+
+	_, err := bf.Exec(schema)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DropConstraint drops the named unique or check constraint from table tn.
+func (bf *BaseFlavor) DropConstraint(tn string, cn string) error {
+
+	schema := "ALTER TABLE " + tn + " DROP CONSTRAINT " + cn + ";"
+	bf.QsLog(schema)
+
+	_, err := bf.Exec(schema)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ExistsConstraint checks the connected database for the presence of the
+// named unique or check constraint on table tn.
+func (bf *BaseFlavor) ExistsConstraint(tn string, cn string) bool {
+
+	n := 0
+	qs := "SELECT COUNT(*) FROM information_schema.table_constraints WHERE table_schema = ? AND table_name = ? AND constraint_name = ?;"
+	dbName, tn := bf.splitSchema(tn)
+
+	bf.QsLog(qs, dbName, tn, cn)
+	bf.db.QueryRow(qs, dbName, tn, cn).Scan(&n)
+	if n > 0 {
+		return true
+	}
+	return false
+}
+
 // CreateSequence may be used to create a new sequence on the
 // currently connected database.
 func (bf *BaseFlavor) CreateSequence(sn string, start int) {
@@ -596,7 +742,8 @@ func (bf *BaseFlavor) GetNextSequenceValue(name string) (int, error) {
 // CreateForeignKey creates a foreign-key on an existing column.
 func (bf *BaseFlavor) CreateForeignKey(i interface{}, ft, rt, ff, rf string) error {
 
-	schema := "ALTER TABLE " + ft + " ADD CONSTRAINT " + "fk_" + ft + "_" + rt + "_" + rf + " FOREIGN KEY(" + ff + ")" + " REFERENCES " + rt + "(" + rf + ");"
+	fkn := "fk_" + bareTableName(ft) + "_" + bareTableName(rt) + "_" + rf
+	schema := "ALTER TABLE " + ft + " ADD CONSTRAINT " + fkn + " FOREIGN KEY(" + ff + ")" + " REFERENCES " + qualifiedName(ft, rt) + "(" + rf + ");"
 	bf.QsLog(schema)
 
 	_, err := bf.Exec(schema)
@@ -637,12 +784,16 @@ func (bf *BaseFlavor) ExistsForeignKeyByFields(i interface{}, ft, rt, ff, rf str
 // SQL Schema Processing
 //===============================================================================
 
-// ProcessSchema processes the schema against the connected DB.
+// ProcessSchema processes the schema against the connected DB.  If a
+// plan is in progress, the schema is added to the plan instead.
 func (bf *BaseFlavor) ProcessSchema(schema string) {
 
 	// MustExec panics on error, so just call it
 	// bf.DB.MustExec(schema)
 	bf.QsLog(schema)
+	if bf.addToPlan(schema) {
+		return
+	}
 	result, err := bf.db.Exec(schema)
 	if err != nil {
 		log.Println("ProcessSchema err:", err)
@@ -762,12 +913,18 @@ func (bf *BaseFlavor) Select(dst interface{}, queryString string, args ...interf
 	return bf.db.Select(dst, queryString)
 }
 
-// Exec runs the queryString against the connected db
+// Exec runs the queryString against the connected db.  If a plan is
+// in progress, the queryString is added to the plan instead.
 func (bf *BaseFlavor) Exec(queryString string, args ...interface{}) (sql.Result, error) {
 
 	var result sql.Result
 	var err error
 
+	if bf.addToPlan(queryString) {
+		bf.QsLog(queryString, args...)
+		return planResult{}, nil
+	}
+
 	if args != nil {
 		bf.QsLog(queryString, args...)
 		queryString = bf.db.Rebind(queryString)
@@ -783,8 +940,17 @@ func (bf *BaseFlavor) Exec(queryString string, args ...interface{}) (sql.Result,
 // If any of the commands encounter an error, the transaction will be
 // cancelled via a Rollback and the error message will be returned to
 // the caller.  It is assumed that tList contains bound queryStrings.
+// If a plan is in progress, the commands are added to the plan instead.
 func (bf *BaseFlavor) ProcessTransaction(tList []string) error {
 
+	if bf.planning {
+		for _, s := range tList {
+			bf.QsLog(s)
+			bf.addToPlan(s)
+		}
+		return nil
+	}
+
 	// begin the transaction
 	tx, err := bf.db.Begin()
 	if err != nil {
@@ -794,7 +960,7 @@ func (bf *BaseFlavor) ProcessTransaction(tList []string) error {
 	// execute each command in the transaction set
 	for _, s := range tList {
 		bf.QsLog(s)
-		_, err = tx.Exec(s, nil)
+		_, err = tx.Exec(s)
 		if err != nil {
 			tx.Rollback()
 			return err
@@ -813,8 +979,8 @@ func (bf *BaseFlavor) ProcessTransaction(tList []string) error {
 // processFKeyTag places the data for foreign-key creation in a slice that will be
 // added to the tc (TblComponents) struct.  Foreign-keys are always created after all
 // tables in a Create / Alter set have been processed in order to provide the greatest
-// chance that the corresponding ref-table/field exist.  Could add the constraint name
-// here...
+// chance that the corresponding ref-table/field exist.  The ref-table is routed to the
+// tenant of the handle, if any.  Could add the constraint name here...
 func (bf *BaseFlavor) processFKeyTag(fkeys []FKeyInfo, ft, ff, rv string) []FKeyInfo {
 
 	tf := strings.Split(rv, "(")
@@ -825,7 +991,7 @@ func (bf *BaseFlavor) processFKeyTag(fkeys []FKeyInfo, ft, ff, rv string) []FKey
 
 	rt := tf[0]
 	rf := tf[1]
-	rt = strings.TrimSpace(rt)
+	rt = bf.tenantTableName(strings.TrimSpace(rt))
 	rf = strings.Replace(rf, ")", "", 2)
 	rf = strings.TrimSpace(rf)
 
@@ -838,6 +1004,21 @@ func (bf *BaseFlavor) processFKeyTag(fkeys []FKeyInfo, ft, ff, rv string) []FKey
 	return append(fkeys, fk)
 }
 
+// userType returns the db type of field fd as given by its sqac:"type:<db_type>"
+// tag, or by its sqac:"size:<n>" tag for string fields; vc(n) where vc is the
+// varying character type of the db.  An empty string is returned if neither
+// of the tags is present.
+func userType(fd common.FieldDef, vc string) string {
+
+	if ut := fd.TagValue("type"); ut != "" {
+		return ut
+	}
+	if sz := fd.TagValue("size"); sz != "" && fd.UnderGoType == "string" {
+		return vc + "(" + sz + ")"
+	}
+	return ""
+}
+
 // processIndexTag is used to create or add to an entry in the working indexes map that is
 // being built in a CreateTable or AlterTable method.
 func (bf *BaseFlavor) processIndexTag(iMap map[string]IndexInfo, tableName string, fieldName string,
@@ -854,7 +1035,7 @@ func (bf *BaseFlavor) processIndexTag(iMap map[string]IndexInfo, tableName strin
 		} else {
 			fldIndex.Unique = false
 		}
-		indexName = indexName + tableName + "_" + fieldName
+		indexName = indexName + bareTableName(tableName) + "_" + fieldName
 		iMap[indexName] = fldIndex
 		return iMap
 	}
@@ -875,6 +1056,200 @@ func (bf *BaseFlavor) processIndexTag(iMap map[string]IndexInfo, tableName strin
 	return iMap
 }
 
+// processConstraintTag is used to create or add to an entry in the working constraints
+// map that is being built in a CreateTable or AlterTable method.  Fields tagged with
+// the same sqac:"constraint:<name>" value are grouped into a single unique constraint.
+func (bf *BaseFlavor) processConstraintTag(cMap map[string]ConstraintInfo, tableName string, fieldName string,
+	constraintName string) map[string]ConstraintInfo {
+
+	// multi-column constraints where the constraint-name is in the map
+	con, ok := cMap[constraintName]
+	if ok {
+		con.Fields = append(con.Fields, fieldName)
+		cMap[constraintName] = con
+		return cMap
+	}
+
+	// add a new unique constraint to the map
+	con.TableName = tableName
+	con.Type = "UNIQUE"
+	con.Fields = append(con.Fields, fieldName)
+	cMap[constraintName] = con
+	return cMap
+}
+
+// processCheckTag adds a check constraint for the field to the working constraints
+// map.  Check constraints are named ck_<table_name>_<field_name>.
+func (bf *BaseFlavor) processCheckTag(cMap map[string]ConstraintInfo, tableName string, fieldName string,
+	expr string) map[string]ConstraintInfo {
+
+	con := ConstraintInfo{
+		TableName: tableName,
+		Type:      "CHECK",
+		Fields:    []string{fieldName},
+		Check:     expr,
+	}
+	cMap["ck_"+bareTableName(tableName)+"_"+fieldName] = con
+	return cMap
+}
+
+// constraintClause returns the CONSTRAINT clause for use in CREATE TABLE and
+// ALTER TABLE ... ADD schemas.
+func (bf *BaseFlavor) constraintClause(cn string, constraint ConstraintInfo) string {
+
+	if constraint.Type == "CHECK" {
+		return "CONSTRAINT " + cn + " CHECK (" + constraint.Check + ")"
+	}
+	return "CONSTRAINT " + cn + " UNIQUE (" + strings.Join(constraint.Fields, ", ") + ")"
+}
+
+// checkTokenRegexp matches the quoted literals, identifiers, numbers and
+// comparison operators of a check constraint expression
+var checkTokenRegexp = regexp.MustCompile(`'(?:[^']|'')*'|[A-Za-z_][A-Za-z0-9_$#]*|[0-9]+(?:\.[0-9]+)?|<=|>=|<>|!=|=|<|>`)
+
+// checkCastRegexp matches the postgres type-casts that are added to the
+// check constraint expressions read back from the db; ::text[] for example
+var checkCastRegexp = regexp.MustCompile(`::[A-Za-z_]+( varying| precision| without time zone| with time zone)?(\[\])?`)
+
+// checkTokens returns the tokens of check constraint expression expr in
+// order of appearance.  The dbs rewrite the expressions they are given (an
+// IN list becomes = ANY (ARRAY[...]) on postgres and a chain of ORs on
+// mssql, mysql prefixes literals with their character set) and postgres
+// returns the definition with its CHECK keyword, so the tokens are
+// normalized to allow the expression of the model to be compared with the
+// definition read back from the db.
+func checkTokens(expr string) []string {
+
+	expr = checkCastRegexp.ReplaceAllString(expr, "")
+	raw := checkTokenRegexp.FindAllString(expr, -1)
+	toks := make([]string, 0, len(raw))
+	for i, t := range raw {
+		if !strings.HasPrefix(t, "'") {
+			t = strings.ToLower(t)
+		}
+		switch {
+		case t == "check" || t == "array":
+			continue
+		case t == "any" && len(toks) > 0 && toks[len(toks)-1] == "=":
+			toks[len(toks)-1] = "in"
+			continue
+		case strings.HasPrefix(t, "_") && i+1 < len(raw) && strings.HasPrefix(raw[i+1], "'"):
+			// character set introducer of a mysql literal (_utf8mb4'a')
+			continue
+		case t == "!=":
+			t = "<>"
+		}
+		toks = append(toks, t)
+	}
+	return inLists(toks)
+}
+
+// inLists rewrites the equality tests of check constraint tokens toks into
+// a common form; a chain of equality tests of the same column joined by OR
+// becomes an IN list (c in 'a' 'b'), and an IN list of a single value an
+// equality test (c = 'a').
+func inLists(toks []string) []string {
+
+	isLit := func(t string) bool {
+		return strings.HasPrefix(t, "'") || t[0] >= '0' && t[0] <= '9'
+	}
+
+	out := make([]string, 0, len(toks))
+	for i := 0; i < len(toks); i++ {
+		c := toks[i]
+		if i+2 >= len(toks) || isLit(c) {
+			out = append(out, c)
+			continue
+		}
+
+		vals := make([]string, 0)
+		j := i + 1
+		switch toks[j] {
+		case "in":
+			for j++; j < len(toks) && isLit(toks[j]); j++ {
+				vals = append(vals, toks[j])
+			}
+		case "=":
+			for j+1 < len(toks) && toks[j] == "=" && isLit(toks[j+1]) {
+				vals = append(vals, toks[j+1])
+				j += 2
+				if j+3 < len(toks) && toks[j] == "or" && toks[j+1] == c && toks[j+2] == "=" && isLit(toks[j+3]) {
+					j += 2
+					continue
+				}
+				break
+			}
+		}
+
+		switch {
+		case len(vals) == 0:
+			out = append(out, c)
+			continue
+		case len(vals) == 1:
+			out = append(out, c, "=", vals[0])
+		default:
+			out = append(out, c, "in")
+			out = append(out, vals...)
+		}
+		i = j - 1
+	}
+	return out
+}
+
+// changedChecks returns the names of the check constraints in cMap whose
+// definition on table tn, as read via r, differs from the model.  Check
+// constraints that do not yet exist are not reported.
+func (bf *BaseFlavor) changedChecks(r checkReader, tn string, cMap map[string]ConstraintInfo) ([]string, error) {
+
+	checks, err := r.readChecks(tn)
+	if err != nil {
+		return nil, err
+	}
+	defs := make(map[string]string)
+	for cn, def := range checks {
+		defs[strings.ToLower(cn)] = def
+	}
+
+	changed := make([]string, 0)
+	for _, cn := range bf.constraintNames(cMap) {
+		if cMap[cn].Type != "CHECK" {
+			continue
+		}
+		def, ok := defs[strings.ToLower(cn)]
+		if !ok {
+			continue
+		}
+		if strings.Join(checkTokens(def), " ") != strings.Join(checkTokens(cMap[cn].Check), " ") {
+			changed = append(changed, cn)
+		}
+	}
+	return changed, nil
+}
+
+// constraintNames returns the names of the constraints in cMap in sorted order
+// so that generated schemas are stable from call to call.
+func (bf *BaseFlavor) constraintNames(cMap map[string]ConstraintInfo) []string {
+
+	names := make([]string, 0, len(cMap))
+	for k := range cMap {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// indexNames returns the names of the indexes in sorted order, so that
+// generated schemas and plans are stable from call to call.
+func indexNames(ind map[string]IndexInfo) []string {
+
+	names := make([]string, 0, len(ind))
+	for in := range ind {
+		names = append(names, in)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Delete - CRUD Delete an existing entity (single-row) on the database using the full-key
 func (bf *BaseFlavor) Delete(ent interface{}) error { // (id uint) error
 
@@ -955,10 +1330,11 @@ func (bf *BaseFlavor) GetEntity(ent interface{}) error {
 		bf.QsLog(selQuery)
 
 		// attempt read the entity row
-		err := bf.db.QueryRowx(selQuery).StructScan(info.ent) //.MapScan(info.resultMap) // SliceScan
+		err := bf.structScan(bf.db.QueryRowx(selQuery), info.ent) //.MapScan(info.resultMap) // SliceScan
 		if err != nil {
 			return err
 		}
+		bf.readTimes(info.ent)
 		info.entValue = reflect.ValueOf(info.ent)
 		return nil
 	}
@@ -976,7 +1352,7 @@ func (bf *BaseFlavor) GetEntities(ents interface{}) (interface{}, error) {
 	testVar := reflect.New(entTypeElem)
 
 	// determine the db table name
-	tn := common.GetTableName(ents)
+	tn := bf.TableName(ents)
 
 	selQuery := "SELECT * FROM " + tn + ";"
 	bf.QsLog(selQuery)
@@ -993,11 +1369,12 @@ func (bf *BaseFlavor) GetEntities(ents interface{}) (interface{}, error) {
 	// into the ents interface (slice)
 	entsv := reflect.ValueOf(ents)
 	for rows.Next() {
-		err = rows.StructScan(testVar.Interface())
+		err = bf.structScan(rows, testVar.Interface())
 		if err != nil {
 			log.Println("GetEntities scan error:", err)
 			return nil, err
 		}
+		bf.readTimes(testVar.Interface())
 		entsv = reflect.Append(entsv, testVar.Elem())
 	}
 
@@ -1050,7 +1427,7 @@ func (bf *BaseFlavor) GetEntities4(ents interface{}) {
 	dstRow := reflect.New(t)
 
 	// determine the db table name
-	tn := common.GetTableName(ents)
+	tn := bf.TableName(ents)
 
 	selQuery := "SELECT * FROM " + tn + ";"
 	bf.QsLog(selQuery)
@@ -1080,10 +1457,11 @@ func (bf *BaseFlavor) GetEntities4(ents interface{}) {
 
 	slice := reflect.MakeSlice(sliceTypeElem, 0, 0)
 	for rows.Next() {
-		err = rows.StructScan(dstRow.Interface())
+		err = bf.structScan(rows, dstRow.Interface())
 		if err != nil {
 			log.Println("GetEntities4 scan error:", err)
 		}
+		bf.readTimes(dstRow.Interface())
 		slice = reflect.Append(slice, dstRow.Elem())
 		results.Set(reflect.Append(results, dstRow.Elem()))
 	}
@@ -1118,15 +1496,19 @@ func (bf *BaseFlavor) GetEntitiesCP(ents interface{}, pList []GetParam, cmdMap m
 	dstRow := reflect.New(t)
 
 	// determine the db table name
-	tn := common.GetTableName(ents)
+	tn := bf.TableName(ents)
 
 	// are there any parameters to include in the query?
 	var pv []interface{}
 	if pList != nil && len(pList) > 0 {
 		paramString = " WHERE"
 		for i := range pList {
-			paramString = paramString + " " + common.CamelToSnake(pList[i].FieldName) + " " + pList[i].Operand + " ? " + pList[i].NextOperator
-			pv = append(pv, pList[i].ParamValue)
+			pred, args, err := bf.paramPredicate(pList[i])
+			if err != nil {
+				return 0, err
+			}
+			paramString = paramString + " " + pred + " " + pList[i].NextOperator
+			pv = append(pv, args...)
 		}
 	}
 
@@ -1255,11 +1637,12 @@ func (bf *BaseFlavor) GetEntitiesCP(ents interface{}, pList []GetParam, cmdMap m
 	var c uint64
 	slice := reflect.MakeSlice(sliceTypeElem, 0, 0)
 	for rows.Next() {
-		err = rows.StructScan(dstRow.Interface())
+		err = bf.structScan(rows, dstRow.Interface())
 		if err != nil {
 			log.Println("GetEntitiesCP scan error:", err)
 			return 0, err
 		}
+		bf.readTimes(dstRow.Interface())
 		slice = reflect.Append(slice, dstRow.Elem())
 		results.Set(reflect.Append(results, dstRow.Elem()))
 		c++
@@ -1289,15 +1672,19 @@ func (bf *BaseFlavor) GetEntitiesWithCommands(ents interface{}, pList []GetParam
 	testVar := reflect.New(entTypeElem)
 
 	// determine the db table name
-	tn := common.GetTableName(ents)
+	tn := bf.TableName(ents)
 
 	// are there any parameters to include in the query?
 	var pv []interface{}
 	if pList != nil && len(pList) > 0 {
 		paramString = " WHERE"
 		for i := range pList {
-			paramString = paramString + " " + common.CamelToSnake(pList[i].FieldName) + " " + pList[i].Operand + " ? " + pList[i].NextOperator
-			pv = append(pv, pList[i].ParamValue)
+			pred, args, err := bf.paramPredicate(pList[i])
+			if err != nil {
+				return nil, err
+			}
+			paramString = paramString + " " + pred + " " + pList[i].NextOperator
+			pv = append(pv, args...)
 		}
 	}
 
@@ -1411,11 +1798,12 @@ func (bf *BaseFlavor) GetEntitiesWithCommands(ents interface{}, pList []GetParam
 	// into the ents interface (slice)
 	entsv := reflect.ValueOf(ents)
 	for rows.Next() {
-		err = rows.StructScan(testVar.Interface())
+		err = bf.structScan(rows, testVar.Interface())
 		if err != nil {
 			log.Println("GetEntitiesWithCommand scan error:", err)
 			return nil, err
 		}
+		bf.readTimes(testVar.Interface())
 		entsv = reflect.Append(entsv, testVar.Elem())
 	}
 	// ents = entsv.Interface()
@@ -0,0 +1,119 @@
+package sqac
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/1414C/sqac/common"
+)
+
+// JSON wraps a struct, map or slice value that is stored in a single json
+// column; jsonb on postgres, json on mysql, text on sqlite, nvarchar(max)
+// on mssql and nclob on hdb.  The wrapped value is marshalled on write
+// and unmarshalled on read.
+//
+//	type Crate struct {
+//		ID    uint64                       `db:"id" sqac:"primary_key:inc"`
+//		Attrs sqac.JSON[map[string]string] `db:"attrs" sqac:"nullable:true"`
+//	}
+type JSON[T any] struct {
+	Data T
+}
+
+// JSONColumn marks JSON as a json column type for the TagReader.
+func (j JSON[T]) JSONColumn() {}
+
+// Value implements driver.Valuer.  A value marshalling to json null is
+// stored as NULL.
+func (j JSON[T]) Value() (driver.Value, error) {
+
+	b, err := json.Marshal(j.Data)
+	if err != nil {
+		return nil, err
+	}
+	if string(b) == "null" {
+		return nil, nil
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner.  NULL is read as the zero-value of the
+// wrapped type.
+func (j *JSON[T]) Scan(src interface{}) error {
+
+	var zero T
+	j.Data = zero
+
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), &j.Data)
+	case []byte:
+		return json.Unmarshal(v, &j.Data)
+	default:
+		return fmt.Errorf("unable to scan %T into a json column", src)
+	}
+}
+
+// MarshalJSON marshals the wrapped value, so that models holding JSON
+// fields serialize without the wrapper.
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+// UnmarshalJSON unmarshals into the wrapped value.
+func (j *JSON[T]) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &j.Data)
+}
+
+// jsonPathRegexp matches dot-separated json paths such as dims.width or
+// items.0.name
+var jsonPathRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// paramColumn returns the column expression of GetParam p.  Parameters
+// holding a JSONPath select the text of the value at the path in the
+// json document of the column.
+func (bf *BaseFlavor) paramColumn(p GetParam) (string, error) {
+
+	cn := common.CamelToSnake(p.FieldName)
+	if p.JSONPath == "" {
+		return cn, nil
+	}
+	if !jsonPathRegexp.MatchString(p.JSONPath) {
+		return "", fmt.Errorf("invalid json path %s for field %s", p.JSONPath, p.FieldName)
+	}
+
+	switch bf.GetDBDriverName() {
+	case "postgres":
+		return cn + " #>> '{" + strings.Replace(p.JSONPath, ".", ",", -1) + "}'", nil
+	case "mysql":
+		return "JSON_UNQUOTE(JSON_EXTRACT(" + cn + ", '" + jsonPath(p.JSONPath) + "'))", nil
+	case "sqlite3":
+		return "CAST(json_extract(" + cn + ", '" + jsonPath(p.JSONPath) + "') AS TEXT)", nil
+	case "mssql", "hdb":
+		return "JSON_VALUE(" + cn + ", '" + jsonPath(p.JSONPath) + "')", nil
+	default:
+		return "", fmt.Errorf("json path predicates are not supported for %s", bf.GetDBDriverName())
+	}
+}
+
+// jsonPath converts dot-separated path p into a sql/json path expression;
+// dims.width becomes $.dims.width and items.0.name becomes $.items[0].name
+func jsonPath(p string) string {
+
+	sb := strings.Builder{}
+	sb.WriteString("$")
+	for _, s := range strings.Split(p, ".") {
+		if _, err := strconv.Atoi(s); err == nil {
+			sb.WriteString("[" + s + "]")
+		} else {
+			sb.WriteString("." + s)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,395 @@
+package sqac
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TableSnapshot records the state of a table, either as derived from its
+// model, or as read from the db.  Create holds the CREATE TABLE statement
+// for the table where it is known.  Snapshots taken from the models can
+// be stored and compared with later versions of the models in order to
+// plan a migration without reference to a db.
+type TableSnapshot struct {
+	Name    string
+	Create  string
+	Columns []ColumnInfo
+	Indexes map[string]IndexInfo
+}
+
+// Snapshot records the state of a list of tables for a db flavor.  The
+// flavor is identified by its driver name.
+type Snapshot struct {
+	Flavor string
+	Tables []TableSnapshot
+}
+
+// Table returns the snapshot of table tn, or false if table tn is not
+// part of the snapshot.
+func (s *Snapshot) Table(tn string) (TableSnapshot, bool) {
+
+	for _, t := range s.Tables {
+		if strings.EqualFold(t.Name, tn) {
+			return t, true
+		}
+	}
+	return TableSnapshot{}, false
+}
+
+// migrationDDL is implemented by the flavors in order to render the
+// statements of a planned migration.
+type migrationDDL interface {
+	tableComponents(tn string, ent interface{}) TblComponents
+	readColumns(tn string) ([]ColumnInfo, error)
+	readCreateSchema(tn string) string
+	normalizeColumn(ci ColumnInfo) ColumnInfo
+	addColumnSQL(tn string, ci ColumnInfo) string
+	dropIndexSQL(tn string, in string) string
+	dropTableSQL(tn string) string
+	CreateTables(i ...interface{}) error
+	CreateIndex(in string, index IndexInfo) error
+	ExistsTable(tn string) bool
+	ExistsIndex(tn string, in string) bool
+}
+
+// columnAlterer is implemented by flavors that are able to alter and
+// drop existing columns in place.
+type columnAlterer interface {
+	alterColumnSQL(tn string, deltas []colDelta) []string
+}
+
+// tableRebuilder is implemented by flavors that need to rebuild a table
+// in order to alter or drop its columns.  The table is rebuilt using the
+// to.Create schema, the columns common to both states are copied, and the
+// columns in keep are carried over from the existing table.
+type tableRebuilder interface {
+	rebuildTableSQL(tn string, from, to TableSnapshot, keep []ColumnInfo) []string
+}
+
+// objectDropper is implemented by flavors that create db objects along
+// with a table, which are not removed by dropping the table.
+type objectDropper interface {
+	dropTableObjectsSQL(tn string, ent interface{}) []string
+}
+
+// ensure that the flavors are able to render migrations
+var (
+	_ migrationDDL   = &PostgresFlavor{}
+	_ migrationDDL   = &MySQLFlavor{}
+	_ migrationDDL   = &SQLiteFlavor{}
+	_ migrationDDL   = &MSSQLFlavor{}
+	_ migrationDDL   = &HDBFlavor{}
+	_ columnAlterer  = &PostgresFlavor{}
+	_ columnAlterer  = &MySQLFlavor{}
+	_ columnAlterer  = &MSSQLFlavor{}
+	_ columnAlterer  = &HDBFlavor{}
+	_ tableRebuilder = &SQLiteFlavor{}
+	_ objectDropper  = &HDBFlavor{}
+)
+
+// tableMigration holds the column differences found for an existing
+// table.
+type tableMigration struct {
+	tn     string
+	from   TableSnapshot
+	to     TableSnapshot
+	deltas []colDelta
+	adds   []ColumnInfo
+}
+
+// SnapshotTables returns a snapshot of the tables described by the
+// models.  Flavors supporting migrations provide their own version.
+func (bf *BaseFlavor) SnapshotTables(i ...interface{}) (Snapshot, error) {
+	return Snapshot{}, fmt.Errorf("method SnapshotTables has not been implemented for %s", bf.GetDBDriverName())
+}
+
+// PlanMigration returns the statements required to migrate the tables
+// to the models (up), and the statements required to revert the
+// migration (down).  Flavors supporting migrations provide their own
+// version.
+func (bf *BaseFlavor) PlanMigration(from *Snapshot, i ...interface{}) ([]string, []string, error) {
+	return nil, nil, fmt.Errorf("method PlanMigration has not been implemented for %s", bf.GetDBDriverName())
+}
+
+// readCreateSchema returns the CREATE TABLE statement of table tn as
+// held by the db.  Only flavors that need the statement in order to
+// revert a migration provide their own version.
+func (bf *BaseFlavor) readCreateSchema(tn string) string {
+	return ""
+}
+
+// dropTableSQL returns the statement used to drop table tn.
+func (bf *BaseFlavor) dropTableSQL(tn string) string {
+	return "DROP TABLE " + tn + ";"
+}
+
+// modelSnapshot returns the snapshot of table tn as described by model ent.
+func (bf *BaseFlavor) modelSnapshot(r migrationDDL, tn string, ent interface{}) TableSnapshot {
+
+	tc := r.tableComponents(tn, ent)
+	return TableSnapshot{
+		Name:    tn,
+		Create:  tc.tblSchema,
+		Columns: bf.modelColumns(tc),
+		Indexes: tc.ind,
+	}
+}
+
+// readSnapshot reads the state of table tn from the db.  Only the model
+// indexes in ind are checked, as indexes that are not part of the model
+// play no part in a migration.  false is returned if the table does not
+// exist.
+func (bf *BaseFlavor) readSnapshot(r migrationDDL, tn string, ind map[string]IndexInfo) (TableSnapshot, bool, error) {
+
+	if !r.ExistsTable(tn) {
+		return TableSnapshot{}, false, nil
+	}
+
+	cols, err := r.readColumns(tn)
+	if err != nil {
+		return TableSnapshot{}, false, err
+	}
+
+	ts := TableSnapshot{
+		Name:    tn,
+		Create:  r.readCreateSchema(tn),
+		Columns: cols,
+		Indexes: make(map[string]IndexInfo),
+	}
+	for in, ix := range ind {
+		if r.ExistsIndex(ix.TableName, in) {
+			ts.Indexes[in] = ix
+		}
+	}
+	return ts, true, nil
+}
+
+// snapshotTables returns a snapshot of the tables described by the models.
+// Models describing views are skipped.
+func (bf *BaseFlavor) snapshotTables(r migrationDDL, i ...interface{}) (Snapshot, error) {
+
+	snap := Snapshot{Flavor: bf.GetDBDriverName()}
+	i, _ = splitViews(i)
+	for _, ent := range i {
+		tn := bf.TableName(ent)
+		if tn == "" {
+			return Snapshot{}, fmt.Errorf("unable to determine table name in SnapshotTables")
+		}
+		snap.Tables = append(snap.Tables, bf.modelSnapshot(r, tn, ent))
+	}
+	return snap, nil
+}
+
+// planMigration compares the models with the previous state of their
+// tables and renders the statements needed to migrate the tables in
+// both directions.  If from is nil, the previous state is read from the
+// db, otherwise the previous state is taken from the snapshot and the db
+// is not consulted.
+//
+// New tables are created in full and dropped on the way down.  Columns
+// and indexes of existing tables are added, altered and dropped, with
+// the dropping of columns subject to the destructive-change policy.
+// Columns dropped by the up migration are re-added as nullable columns
+// on the way down; their data is not recovered.  Tables that are not
+// part of the models are not touched, and foreign-key and constraint
+// changes on existing tables are left to AlterTables, as are column
+// and table renames requested via renamed_from tags.  Models describing
+// views are skipped; views are (re)created by CreateTables and AlterTables.
+func (bf *BaseFlavor) planMigration(r migrationDDL, from *Snapshot, i ...interface{}) ([]string, []string, error) {
+
+	if from != nil && from.Flavor != "" && from.Flavor != bf.GetDBDriverName() {
+		return nil, nil, fmt.Errorf("snapshot was taken for %s, not %s", from.Flavor, bf.GetDBDriverName())
+	}
+
+	news := make([]interface{}, 0)
+	newTns := make([]string, 0)
+	changes := make([]tableMigration, 0)
+
+	i, _ = splitViews(i)
+	for _, ent := range i {
+
+		tn := bf.TableName(ent)
+		if tn == "" {
+			return nil, nil, fmt.Errorf("unable to determine table name in PlanMigration")
+		}
+		to := bf.modelSnapshot(r, tn, ent)
+
+		var prev TableSnapshot
+		var ok bool
+		var err error
+		if from == nil {
+			prev, ok, err = bf.readSnapshot(r, tn, to.Indexes)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else {
+			prev, ok = from.Table(tn)
+		}
+		if !ok {
+			news = append(news, ent)
+			newTns = append(newTns, tn)
+			continue
+		}
+
+		deltas := bf.diffColumns(to.Columns, prev.Columns, r.normalizeColumn)
+		deltas, err = bf.applyDestructivePolicy(tn, deltas)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		changes = append(changes, tableMigration{
+			tn:     tn,
+			from:   prev,
+			to:     to,
+			deltas: deltas,
+			adds:   missingColumns(to.Columns, prev.Columns),
+		})
+	}
+
+	up, err := bf.runPlan(func() error {
+
+		// new tables are created even if a table of the same name exists in
+		// the connected db, as the previous state may have come from a snapshot
+		if len(news) > 0 {
+			for _, tn := range newTns {
+				bf.dropInPlan(tn)
+			}
+			err := r.CreateTables(news...)
+			if err != nil {
+				return err
+			}
+		}
+		for _, m := range changes {
+			err := bf.migrateTable(r, m.tn, m.from, m.to, m.deltas, m.adds)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	down, err := bf.runPlan(func() error {
+
+		for k := len(changes) - 1; k >= 0; k-- {
+			m := changes[k]
+			deltas := make([]colDelta, 0)
+			readds := make([]ColumnInfo, 0)
+			for _, d := range m.deltas {
+				switch d.op {
+				case "ALTER":
+					deltas = append(deltas, colDelta{op: "ALTER", name: d.name, from: d.to, to: d.from,
+						typeChg: d.typeChg, nullChg: d.nullChg, dfltChg: d.dfltChg})
+				case "DROP":
+					c := d.from
+					c.Nullable = true
+					readds = append(readds, c)
+				}
+			}
+			for _, c := range m.adds {
+				deltas = append(deltas, colDelta{op: "DROP", name: c.Name, from: c})
+			}
+
+			// columns retained by the up migration remain part of the table
+			cur := m.to
+			cur.Columns = append(append([]ColumnInfo{}, m.to.Columns...), retainedColumns(m.from, m.to, m.deltas)...)
+			err := bf.migrateTable(r, m.tn, cur, m.from, deltas, readds)
+			if err != nil {
+				return err
+			}
+		}
+		for k := len(newTns) - 1; k >= 0; k-- {
+			bf.addToPlan(r.dropTableSQL(newTns[k]))
+			if od, ok := r.(objectDropper); ok {
+				for _, s := range od.dropTableObjectsSQL(newTns[k], news[k]) {
+					bf.addToPlan(s)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return up, down, nil
+}
+
+// migrateTable adds the statements required to take table tn from one
+// state to the other to the plan in progress.
+func (bf *BaseFlavor) migrateTable(r migrationDDL, tn string, from, to TableSnapshot, deltas []colDelta, adds []ColumnInfo) error {
+
+	rebuilt := false
+	if rb, ok := r.(tableRebuilder); ok && len(deltas) > 0 {
+		// carry over the columns that are retained as per the destructive-
+		// change policy.  columns being added are part of to.Create.
+		keep := retainedColumns(from, to, deltas)
+		for _, s := range rb.rebuildTableSQL(tn, from, to, keep) {
+			bf.addToPlan(s)
+		}
+		rebuilt = true
+	} else {
+		for _, c := range adds {
+			bf.addToPlan(r.addColumnSQL(tn, c))
+		}
+		if ca, ok := r.(columnAlterer); ok {
+			for _, s := range ca.alterColumnSQL(tn, deltas) {
+				bf.addToPlan(s)
+			}
+		}
+	}
+
+	// a rebuilt table has lost its indexes
+	for _, in := range indexNames(from.Indexes) {
+		if _, ok := to.Indexes[in]; !ok && !rebuilt {
+			bf.addToPlan(r.dropIndexSQL(tn, in))
+		}
+	}
+	for _, in := range indexNames(to.Indexes) {
+		if _, ok := from.Indexes[in]; !ok || rebuilt {
+			err := r.CreateIndex(in, to.Indexes[in])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// retainedColumns returns the columns of from that are not part of to,
+// and are not dropped by the deltas.
+func retainedColumns(from, to TableSnapshot, deltas []colDelta) []ColumnInfo {
+
+	dropped := make(map[string]bool)
+	for _, d := range deltas {
+		if d.op == "DROP" {
+			dropped[strings.ToLower(d.name)] = true
+		}
+	}
+
+	keep := make([]ColumnInfo, 0)
+	for _, c := range missingColumns(from.Columns, to.Columns) {
+		if !dropped[strings.ToLower(c.Name)] {
+			keep = append(keep, c)
+		}
+	}
+	return keep
+}
+
+// missingColumns returns the columns in want that are not found in have.
+func missingColumns(want, have []ColumnInfo) []ColumnInfo {
+
+	found := make(map[string]bool)
+	for _, h := range have {
+		found[strings.ToLower(h.Name)] = true
+	}
+
+	cols := make([]ColumnInfo, 0)
+	for _, w := range want {
+		if !found[strings.ToLower(w.Name)] {
+			cols = append(cols, w)
+		}
+	}
+	return cols
+}
@@ -0,0 +1,146 @@
+package sqac
+
+import (
+	"fmt"
+	"strings"
+)
+
+// planResult is the sql.Result returned by Exec for statements that
+// are added to a plan rather than being executed.
+type planResult struct{}
+
+// LastInsertId is not available for planned statements.
+func (planResult) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+// RowsAffected reports no rows for planned statements.
+func (planResult) RowsAffected() (int64, error) {
+	return 0, nil
+}
+
+// runPlan calls fn with plan mode active and returns the statements that
+// were recorded along the way.  In plan mode, ProcessSchema, Exec and
+// ProcessTransaction add their statements to the plan instead of
+// executing them.  The db continues to be read in order to determine the
+// existing state of the tables, indexes, sequences and keys, so a plan
+// reflects the statements that would be executed against the connected
+// db at the time of the call.  Plan mode is not safe for concurrent use
+// with other operations on the same handle.
+func (bf *BaseFlavor) runPlan(fn func() error) ([]string, error) {
+
+	if bf.planning {
+		return nil, fmt.Errorf("a plan is already in progress on this handle")
+	}
+
+	bf.planning = true
+	bf.plan = make([]string, 0)
+	bf.planDrops = make(map[string]bool)
+	bf.planRenames = make(map[string]map[string]string)
+	defer func() {
+		bf.planning = false
+		bf.plan = nil
+		bf.planDrops = nil
+		bf.planRenames = nil
+	}()
+
+	err := fn()
+	if err != nil {
+		return nil, err
+	}
+	return bf.plan, nil
+}
+
+// addToPlan adds the statement to the plan and returns true if a plan
+// is in progress.  If there is no plan in progress, false is returned
+// and the caller is expected to execute the statement.
+func (bf *BaseFlavor) addToPlan(stmt string) bool {
+
+	if !bf.planning {
+		return false
+	}
+	bf.plan = append(bf.plan, stmt)
+	return true
+}
+
+// dropInPlan notes that table tn is dropped by the plan in progress, so
+// that subsequent steps of the plan treat the table as absent.
+func (bf *BaseFlavor) dropInPlan(tn string) {
+
+	if bf.planning {
+		bf.planDrops[strings.ToLower(tn)] = true
+	}
+}
+
+// renameInPlan notes that column from of table tn has been renamed to
+// column to by the plan in progress.
+func (bf *BaseFlavor) renameInPlan(tn, from, to string) {
+
+	if !bf.planning {
+		return
+	}
+	tn = strings.ToLower(tn)
+	if bf.planRenames[tn] == nil {
+		bf.planRenames[tn] = make(map[string]string)
+	}
+	bf.planRenames[tn][strings.ToLower(from)] = to
+}
+
+// planColumns applies the column renames of the plan in progress to the
+// columns of table tn as read from the db, so that subsequent steps of
+// the plan see the columns under their new names.
+func (bf *BaseFlavor) planColumns(tn string, cols []ColumnInfo) []ColumnInfo {
+
+	renames := bf.planRenames[strings.ToLower(tn)]
+	if !bf.planning || len(renames) == 0 {
+		return cols
+	}
+	for i := range cols {
+		if to, ok := renames[strings.ToLower(cols[i].Name)]; ok {
+			cols[i].Name = to
+		}
+	}
+	return cols
+}
+
+// absentInPlan reports whether table tn is known to be absent for the
+// plan in progress, either because it has been dropped earlier in the
+// plan, or because the handle is offline and therefore holds no tables.
+func (bf *BaseFlavor) absentInPlan(tn string) bool {
+	return bf.planning && (bf.offline || bf.planDrops[strings.ToLower(tn)])
+}
+
+// mustExec executes the schema against the connected db and panics on
+// error, or adds the schema to the plan if a plan is in progress.
+func (bf *BaseFlavor) mustExec(schema string) {
+
+	if bf.addToPlan(schema) {
+		return
+	}
+	bf.db.MustExec(schema)
+}
+
+// PlanCreateTables returns the statements that CreateTables would execute.
+// Flavors that implement CreateTables provide their own version.
+func (bf *BaseFlavor) PlanCreateTables(i ...interface{}) ([]string, error) {
+	return bf.runPlan(func() error { return bf.CreateTables(i...) })
+}
+
+// PlanAlterTables returns the statements that AlterTables would execute.
+// Flavors that implement AlterTables provide their own version.
+func (bf *BaseFlavor) PlanAlterTables(i ...interface{}) ([]string, error) {
+	return bf.runPlan(func() error { return bf.AlterTables(i...) })
+}
+
+// PlanDropTables returns the statements that DropTables would execute.
+// Flavors that implement DropTables provide their own version.
+func (bf *BaseFlavor) PlanDropTables(i ...interface{}) ([]string, error) {
+	return bf.runPlan(func() error { return bf.DropTables(i...) })
+}
+
+// PlanDestructiveResetTables returns the statements that DestructiveResetTables
+// would execute.  Flavors that implement DestructiveResetTables provide their
+// own version.
+func (bf *BaseFlavor) PlanDestructiveResetTables(i ...interface{}) ([]string, error) {
+	return bf.runPlan(func() error { return bf.DestructiveResetTables(i...) })
+}
@@ -0,0 +1,122 @@
+package sqac
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/1414C/sqac/common"
+)
+
+// procParam is a stored procedure parameter mapped from a struct field.
+// The parameter is named after the db tag of the field, or failing that,
+// the snake_case go name of the field.
+type procParam struct {
+	name  string
+	value reflect.Value
+}
+
+// CallProcedure calls stored procedure name on the connected db.  Flavors
+// supporting stored procedures provide their own version.
+func (bf *BaseFlavor) CallProcedure(name string, in interface{}, out interface{}) error {
+	return fmt.Errorf("method CallProcedure has not been implemented for %s", bf.GetDBDriverName())
+}
+
+// procParams maps the exported fields of struct i onto procedure
+// parameters, in field order.  i may be nil, a struct or a pointer to a
+// struct; fields tagged db:"-" are skipped.
+func procParams(i interface{}) ([]procParam, error) {
+
+	if i == nil {
+		return nil, nil
+	}
+	v := reflect.ValueOf(i)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("procedure parameters must be passed in a struct{} - got %s", v.Kind())
+	}
+
+	ps := make([]procParam, 0)
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		n := f.Tag.Get("db")
+		if n == "-" {
+			continue
+		}
+		if n == "" {
+			n = common.CamelToSnake(f.Name)
+		}
+		ps = append(ps, procParam{name: n, value: v.Field(i)})
+	}
+	return ps, nil
+}
+
+// procOutParams maps the fields of out onto the OUT parameters of a
+// procedure.  out must be nil or a pointer to a struct, as the values of
+// the OUT parameters are written to its fields.  A pointer to a slice
+// receives the result set of the procedure and maps to no parameters.
+func procOutParams(out interface{}) ([]procParam, error) {
+
+	if out == nil || isResultSet(out) {
+		return nil, nil
+	}
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("procedure OUT parameters must be read into a pointer to a struct{} - got %s", v.Kind())
+	}
+	return procParams(out)
+}
+
+// isResultSet reports whether out is a pointer to a slice, which receives
+// the rows of the result set of a procedure.
+func isResultSet(out interface{}) bool {
+	v := reflect.ValueOf(out)
+	return v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Slice
+}
+
+// procArgs returns the values of procedure parameters ps.
+func procArgs(ps []procParam) []interface{} {
+
+	args := make([]interface{}, 0, len(ps))
+	for _, p := range ps {
+		args = append(args, p.value.Interface())
+	}
+	return args
+}
+
+// outArg returns the argument receiving the value of OUT parameter p.
+func (p procParam) outArg() sql.Out {
+	return sql.Out{Dest: p.value.Addr().Interface()}
+}
+
+// placeholders returns a list of n '?' parameter placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// queryProcedure runs the procedure call in qs and reads its result into
+// out: the rows of the result set into a pointer to a slice, the single
+// result row into a pointer to a struct.  The result is discarded if out
+// is nil.
+func (bf *BaseFlavor) queryProcedure(out interface{}, qs string, args ...interface{}) error {
+
+	qs = bf.db.Rebind(qs)
+	bf.QsLog(qs, args...)
+
+	var err error
+	switch {
+	case out == nil:
+		_, err = bf.db.Exec(qs, args...)
+	case isResultSet(out):
+		err = bf.db.Select(out, qs, args...)
+	default:
+		err = bf.db.Get(out, qs, args...)
+	}
+	return err
+}
@@ -0,0 +1,388 @@
+package sqac
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strings"
+
+	"github.com/1414C/sqac/common"
+)
+
+// TableInfo describes an existing table as read from the db.  PrimaryKey
+// lists the primary-key columns in column order.  The primary-key index
+// and the indexes backing constraints are not part of Indexes.
+type TableInfo struct {
+	Name        string
+	Columns     []ColumnInfo
+	PrimaryKey  []string
+	Indexes     map[string]IndexInfo
+	ForeignKeys []FKeyInfo
+	Sequences   []SequenceInfo
+}
+
+// SequenceInfo describes the sequence, auto-increment or identity counter
+// feeding a column.  Name holds the name of the sequence for the dbs that
+// use named sequences (postgres, hdb), and the table name otherwise.
+type SequenceInfo struct {
+	Name   string
+	Column string
+}
+
+// schemaReader is implemented by the flavors in order to read the
+// definitions of existing tables from the connected db.
+type schemaReader interface {
+	readTableNames() ([]string, error)
+	readColumns(tn string) ([]ColumnInfo, error)
+	readIndexes(tn string) (map[string]IndexInfo, error)
+	readForeignKeys(tn string) ([]FKeyInfo, error)
+	sequenceName(tn string, ci ColumnInfo) string
+	normalizeColumn(ci ColumnInfo) ColumnInfo
+}
+
+// ensure that the flavors are able to read existing tables
+var (
+	_ schemaReader = &PostgresFlavor{}
+	_ schemaReader = &MySQLFlavor{}
+	_ schemaReader = &SQLiteFlavor{}
+	_ schemaReader = &MSSQLFlavor{}
+	_ schemaReader = &HDBFlavor{}
+)
+
+// goIdentRegexp matches the names that can be used as go identifiers
+// once their first letter has been upper-cased.
+var goIdentRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// DescribeTable reads the definition of table tn from the connected db.
+// Flavors that are able to read their tables provide their own version.
+func (bf *BaseFlavor) DescribeTable(tn string) (TableInfo, error) {
+	return TableInfo{}, fmt.Errorf("method DescribeTable has not been implemented for %s", bf.GetDBDriverName())
+}
+
+// describeTable reads the columns, indexes, foreign-keys and sequences of
+// table tn via r.
+func (bf *BaseFlavor) describeTable(r schemaReader, tn string) (TableInfo, error) {
+
+	ti := TableInfo{Name: tn}
+
+	cols, err := r.readColumns(tn)
+	if err != nil {
+		return ti, err
+	}
+	if len(cols) == 0 {
+		return ti, fmt.Errorf("table %s was not found", tn)
+	}
+	ti.Columns = cols
+
+	err = bf.readEnums(r, tn, cols)
+	if err != nil {
+		return ti, err
+	}
+
+	ti.Indexes, err = r.readIndexes(tn)
+	if err != nil {
+		return ti, err
+	}
+	ti.ForeignKeys, err = r.readForeignKeys(tn)
+	if err != nil {
+		return ti, err
+	}
+
+	for _, ci := range cols {
+		if ci.PrimaryKey {
+			ti.PrimaryKey = append(ti.PrimaryKey, ci.Name)
+		}
+		if ci.Identity {
+			ti.Sequences = append(ti.Sequences, SequenceInfo{Name: r.sequenceName(tn, ci), Column: ci.Name})
+		}
+	}
+	return ti, nil
+}
+
+// sequenceName returns the name of the counter feeding identity column ci
+// of table tn.  Auto-increment and identity counters belong to their
+// table, so the table name is returned by default.
+func (bf *BaseFlavor) sequenceName(tn string, ci ColumnInfo) string {
+	return tn
+}
+
+// GenerateModels reads the named tables from the connected db and returns
+// the source of a go file in package pkg holding a sqac-tagged model for
+// each of them.  All tables of the db are read if no table names are
+// provided.  Flavors that are able to read their tables provide their
+// own version.
+func (bf *BaseFlavor) GenerateModels(pkg string, tn ...string) ([]byte, error) {
+	return nil, fmt.Errorf("method GenerateModels has not been implemented for %s", bf.GetDBDriverName())
+}
+
+// generateModels reads the tables via r and renders their models.  The
+// model type names lower-case to the table names, and the field names
+// snake-case to the column names, as required by GetTableName and
+// TagReader.  Nullable columns are mapped to pointer types, and the
+// primary-key, nullable, default, index and fkey tags are set from the
+// column, index and foreign-key definitions read from the db.  Tables
+// created from the models name their single-column indexes as per the
+// sqac convention, and group the columns of unique multi-column indexes
+// in a unique constraint.
+func (bf *BaseFlavor) generateModels(r schemaReader, pkg string, tns ...string) ([]byte, error) {
+
+	var err error
+	if len(tns) == 0 {
+		tns, err = r.readTableNames()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var body bytes.Buffer
+	useTime, useSqac := false, false
+	for _, tn := range tns {
+		src, pkgs, err := bf.modelSource(r, tn)
+		if err != nil {
+			return nil, err
+		}
+		body.WriteString(src)
+		useTime = useTime || pkgs["time"]
+		useSqac = useSqac || pkgs["sqac"]
+	}
+
+	var sb bytes.Buffer
+	fmt.Fprintf(&sb, "// Models generated by sqac from the tables of the %s db.\n\npackage %s\n\n", bf.GetDBDriverName(), pkg)
+	switch {
+	case useTime && useSqac:
+		sb.WriteString("import (\n\t\"time\"\n\n\t\"github.com/1414C/sqac\"\n)\n\n")
+	case useTime:
+		sb.WriteString("import \"time\"\n\n")
+	case useSqac:
+		sb.WriteString("import \"github.com/1414C/sqac\"\n\n")
+	}
+	sb.Write(body.Bytes())
+
+	return format.Source(sb.Bytes())
+}
+
+// modelSource renders the model of table tn, and reports the packages
+// (time, sqac) that the model makes use of.
+func (bf *BaseFlavor) modelSource(r schemaReader, tn string) (string, map[string]bool, error) {
+
+	tn = strings.ToLower(tn)
+	if !goIdentRegexp.MatchString(tn) {
+		return "", nil, fmt.Errorf("table %s cannot be mapped to a go type name", tn)
+	}
+	typeName := strings.ToUpper(tn[:1]) + tn[1:]
+
+	ti, err := bf.describeTable(r, tn)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// index and foreign-key tags by column
+	extra := make(map[string][]string)
+	for _, in := range indexNames(ti.Indexes) {
+		ix := ti.Indexes[in]
+		for _, f := range ix.IndexFields {
+			f = strings.ToLower(f)
+			switch {
+			case len(ix.IndexFields) == 1 && ix.Unique:
+				extra[f] = append(extra[f], "index:unique")
+			case len(ix.IndexFields) == 1:
+				extra[f] = append(extra[f], "index:non-unique")
+			case ix.Unique:
+				extra[f] = append(extra[f], "constraint:"+strings.ToLower(in))
+			default:
+				extra[f] = append(extra[f], "index:"+strings.ToLower(in))
+			}
+		}
+	}
+	for _, fk := range ti.ForeignKeys {
+		f := strings.ToLower(fk.FromField)
+		extra[f] = append(extra[f], "fkey:"+strings.ToLower(fk.RefTable)+"("+strings.ToLower(fk.RefField)+")")
+	}
+
+	// the string columns of the default type need no size tag
+	dflt := "varchar(255)"
+	switch bf.GetDBDriverName() {
+	case "postgres":
+		dflt = "text"
+	case "hdb":
+		dflt = "nvarchar(255)"
+	}
+
+	var sb strings.Builder
+	pkgs := make(map[string]bool)
+	fmt.Fprintf(&sb, "// %s maps table %s.\ntype %s struct {\n", typeName, tn, typeName)
+	for _, ci := range ti.Columns {
+		cn := strings.ToLower(ci.Name)
+		fn := goFieldName(cn)
+		if fn == "" {
+			return "", nil, fmt.Errorf("column %s of table %s cannot be mapped to a go field name", cn, tn)
+		}
+
+		ft, known := goType(ci.Type)
+		if i := strings.Index(ft, "."); i != -1 {
+			pkgs[ft[:i]] = true
+		}
+
+		// binary data, decimals and dates store NULL as their zero value
+		if ci.Nullable && !ci.PrimaryKey && ft != "[]byte" && ft != "sqac.Decimal" && ft != "sqac.Date" {
+			ft = "*" + ft
+		}
+
+		pairs := make([]string, 0)
+		switch {
+		case ci.PrimaryKey && ci.Identity:
+			pairs = append(pairs, "primary_key:inc")
+		case ci.PrimaryKey:
+			pairs = append(pairs, "primary_key:")
+		default:
+			pairs = append(pairs, fmt.Sprintf("nullable:%v", ci.Nullable))
+		}
+		d := r.normalizeColumn(ci).Default
+		if d != "" && !ci.Identity && !strings.ContainsAny(d, ";\"`") {
+			pairs = append(pairs, "default:"+d)
+		}
+		if len(ci.Enum) > 0 && !strings.ContainsAny(strings.Join(ci.Enum, ""), ";\"`") {
+			pairs = append(pairs, "enum:"+strings.Join(ci.Enum, "|"))
+		}
+		if !strings.EqualFold(ci.Type, dflt) {
+			pairs = append(pairs, typeTags(ci.Type)...)
+		}
+		pairs = append(pairs, extra[cn]...)
+
+		fmt.Fprintf(&sb, "\t%s %s `json:\"%s\" db:\"%s\" sqac:\"%s\"`", fn, ft, cn, cn, strings.Join(pairs, ";"))
+		if !known {
+			fmt.Fprintf(&sb, " // db type %s", ci.Type)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n\n")
+	return sb.String(), pkgs, nil
+}
+
+// typeSizeRegexp matches the sized character and decimal db types;
+// varchar(40), character varying(40), nvarchar(40), numeric(12,2)
+var typeSizeRegexp = regexp.MustCompile(`^(varchar|character varying|nvarchar|numeric|decimal)\(([0-9]+)(?:, ?([0-9]+))?\)$`)
+
+// typeTags returns the size tag of a sized character db type, and the
+// precision and scale tags of a decimal db type.
+func typeTags(dbType string) []string {
+
+	m := typeSizeRegexp.FindStringSubmatch(strings.ToLower(strings.TrimSpace(dbType)))
+	if m == nil {
+		return nil
+	}
+	switch m[1] {
+	case "numeric", "decimal":
+		tags := []string{"precision:" + m[2]}
+		if m[3] != "" {
+			tags = append(tags, "scale:"+m[3])
+		}
+		return tags
+	default:
+		return []string{"size:" + m[2]}
+	}
+}
+
+// goFieldName returns the exported go field name for column cn, or an
+// empty string if there is no field name that TagReader maps back onto
+// the column.
+func goFieldName(cn string) string {
+
+	var sb strings.Builder
+	for _, p := range strings.Split(cn, "_") {
+		switch {
+		case p == "id":
+			sb.WriteString("ID")
+		case p != "":
+			sb.WriteString(strings.ToUpper(p[:1]) + p[1:])
+		}
+	}
+	fn := sb.String()
+	if !goIdentRegexp.MatchString(fn) || common.CamelToSnake(fn) != cn {
+		return ""
+	}
+	return fn
+}
+
+// goType maps a db type as reported by the db onto the go type that sqac maps onto
+// the db type, and reports whether the db type is known.  Unknown types
+// are mapped to string.
+func goType(dbType string) (string, bool) {
+
+	t := strings.ToLower(strings.TrimSpace(dbType))
+	word := t
+	if i := strings.IndexAny(t, " ("); i != -1 {
+		word = t[:i]
+	}
+	unsigned := strings.Contains(t, "unsigned")
+
+	switch word {
+	case "bool", "boolean", "bit":
+		return "bool", true
+
+	case "tinyint":
+		if strings.HasPrefix(t, "tinyint(1)") {
+			return "bool", true
+		}
+		if unsigned {
+			return "uint", true
+		}
+		return "int", true
+
+	case "bigint", "int8", "bigserial":
+		if unsigned {
+			return "uint64", true
+		}
+		return "int64", true
+
+	case "int", "integer", "int4", "int2", "smallint", "mediumint", "serial", "smallserial":
+		if unsigned {
+			return "uint", true
+		}
+		return "int", true
+
+	case "numeric", "decimal":
+		// sqac maps float fields onto numeric on postgres, and Decimal
+		// fields onto decimal(p,s) / numeric(p,s) everywhere
+		if strings.Contains(t, "(") {
+			return "sqac.Decimal", true
+		}
+		return "float64", true
+
+	case "real", "float", "float4", "float8", "double", "smalldecimal", "money", "smallmoney":
+		return "float64", true
+
+	case "date":
+		return "sqac.Date", true
+
+	case "time":
+		return "sqac.TimeOfDay", true
+
+	case "timestamp", "timestamptz", "datetime", "datetime2", "smalldatetime", "datetimeoffset", "seconddate":
+		return "time.Time", true
+
+	case "bytea", "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary", "image":
+		return "[]byte", true
+
+	case "char", "character", "varchar", "varchar2", "nchar", "nvarchar", "text", "ntext", "tinytext", "mediumtext", "longtext",
+		"clob", "nclob", "shorttext", "alphanum", "uuid", "uniqueidentifier", "enum":
+		return "string", true
+
+	default:
+		return "string", false
+	}
+}
+
+// addIndexField adds column cn to index in of table tn in map ind.  The
+// columns are expected to be added in the order of their position in the
+// index.
+func addIndexField(ind map[string]IndexInfo, tn, in string, unique bool, cn string) {
+
+	ix, ok := ind[in]
+	if !ok {
+		ix = IndexInfo{TableName: tn, Unique: unique}
+	}
+	ix.IndexFields = append(ix.IndexFields, cn)
+	ind[in] = ix
+}
@@ -0,0 +1,191 @@
+package sqac
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/1414C/sqac/common"
+)
+
+// schemaDDL is implemented by the flavors in order to create and drop
+// schemas.
+type schemaDDL interface {
+	createSchemaSQL(sn string) (string, error)
+	dropSchemaSQL(sn string) (string, error)
+	ExistsSchema(sn string) bool
+}
+
+// ensure that the flavors are able to manage schemas
+var (
+	_ schemaDDL = &PostgresFlavor{}
+	_ schemaDDL = &MySQLFlavor{}
+	_ schemaDDL = &SQLiteFlavor{}
+	_ schemaDDL = &MSSQLFlavor{}
+	_ schemaDDL = &HDBFlavor{}
+)
+
+// SetSchema sets the schema holding the tables of models that do not name
+// a schema of their own.  An empty schema name selects the default schema
+// of the connection.
+func (bf *BaseFlavor) SetSchema(sn string) {
+	bf.schema = sn
+}
+
+// GetSchema returns the schema set via SetSchema.
+func (bf *BaseFlavor) GetSchema() string {
+	return bf.schema
+}
+
+// SetTableNaming sets the options used to derive the table names of the
+// models that do not name their table explicitly.
+func (bf *BaseFlavor) SetTableNaming(n common.TableNaming) {
+	bf.tableNaming = n
+}
+
+// GetTableNaming returns the options set via SetTableNaming.
+func (bf *BaseFlavor) GetTableNaming() common.TableNaming {
+	return bf.tableNaming
+}
+
+// TableName returns the name of the table of model ent, qualified by the
+// schema of the model (see common.GetTableSchema) or, failing that, by the
+// schema set via SetSchema.  The table name is not qualified if neither
+// names a schema.  On handles obtained via ForTenant, the name is routed
+// to the table of the tenant first.
+func (bf *BaseFlavor) TableName(ent interface{}) string {
+
+	tn := bf.tenantTableName(bf.tableNaming.TableName(ent))
+	if isQualified(tn) {
+		return tn
+	}
+	sn := common.GetTableSchema(ent)
+	if sn == "" {
+		sn = bf.schema
+	}
+	if sn == "" || tn == "" {
+		return tn
+	}
+	return sn + "." + tn
+}
+
+// CreateSchema creates schema sn on the connected db.  Flavors supporting
+// schemas provide their own version.
+func (bf *BaseFlavor) CreateSchema(sn string) error {
+	return fmt.Errorf("method CreateSchema has not been implemented for %s", bf.GetDBDriverName())
+}
+
+// DropSchema drops schema sn on the connected db.  Flavors supporting
+// schemas provide their own version.
+func (bf *BaseFlavor) DropSchema(sn string) error {
+	return fmt.Errorf("method DropSchema has not been implemented for %s", bf.GetDBDriverName())
+}
+
+// ExistsSchema checks the connected db for the presence of schema sn.
+// Flavors supporting schemas provide their own version.
+func (bf *BaseFlavor) ExistsSchema(sn string) bool {
+	log.Printf("method ExistsSchema has not been implemented for %s\n", bf.GetDBDriverName())
+	return false
+}
+
+// createSchema creates schema sn via r if it does not exist.
+func (bf *BaseFlavor) createSchema(r schemaDDL, sn string) error {
+
+	if sn == "" || (!bf.offline && r.ExistsSchema(sn)) {
+		return nil
+	}
+	schema, err := r.createSchemaSQL(sn)
+	if err != nil {
+		return err
+	}
+	bf.QsLog(schema)
+	_, err = bf.Exec(schema)
+	return err
+}
+
+// dropSchema drops schema sn via r if it exists.
+func (bf *BaseFlavor) dropSchema(r schemaDDL, sn string) error {
+
+	if sn == "" || !r.ExistsSchema(sn) {
+		return nil
+	}
+	schema, err := r.dropSchemaSQL(sn)
+	if err != nil {
+		return err
+	}
+	bf.QsLog(schema)
+	_, err = bf.Exec(schema)
+	return err
+}
+
+// createTableSchemas creates the schemas of the tables of the models that
+// do not exist yet.
+func (bf *BaseFlavor) createTableSchemas(r schemaDDL, i ...interface{}) error {
+
+	done := make(map[string]bool)
+	for _, ent := range i {
+		sn, _ := splitTableName(bf.TableName(ent))
+		if sn == "" || done[sn] {
+			continue
+		}
+		err := bf.createSchema(r, sn)
+		if err != nil {
+			return err
+		}
+		done[sn] = true
+	}
+	return nil
+}
+
+// splitTableName splits a schema-qualified table name into its schema and
+// table name.  The schema is empty if tn is not qualified.
+func splitTableName(tn string) (string, string) {
+
+	if i := strings.LastIndex(tn, "."); i != -1 {
+		return tn[:i], tn[i+1:]
+	}
+	return "", tn
+}
+
+// splitSchema splits table name tn into its schema and table name, with
+// the schema defaulting to the connected db.  This suits the dbs that
+// equate schemas with databases.
+func (bf *BaseFlavor) splitSchema(tn string) (string, string) {
+
+	sn, n := splitTableName(tn)
+	if sn == "" {
+		sn = bf.GetDBName()
+	}
+	return sn, n
+}
+
+// quotedTableName quotes the schema and table name parts of table name tn
+// with quote character qt.
+func quotedTableName(tn, qt string) string {
+
+	sn, n := splitTableName(tn)
+	if sn == "" {
+		return qt + n + qt
+	}
+	return qt + sn + qt + "." + qt + n + qt
+}
+
+// bareTableName returns table name tn without its schema.  Names derived
+// from table names, such as index, foreign-key and sequence names, are
+// based on the bare table name.
+func bareTableName(tn string) string {
+	_, n := splitTableName(tn)
+	return n
+}
+
+// qualifiedName qualifies name with the schema of table tn, unless name
+// is qualified already.  Objects related to a table, such as referenced
+// tables and sequences, are taken to live in the schema of the table.
+func qualifiedName(tn, name string) string {
+
+	sn, _ := splitTableName(tn)
+	if sn == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return sn + "." + name
+}
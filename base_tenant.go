@@ -0,0 +1,62 @@
+package sqac
+
+import (
+	"log"
+	"strings"
+)
+
+// TenantResolver maps table name tn onto the table holding the data of
+// tenant.  The returned name may be schema-qualified, in which case it is
+// used as-is; otherwise it is qualified by the schema of the model or the
+// handle as usual.
+type TenantResolver func(tenant, tn string) string
+
+// TenantSchema is the default TenantResolver.  It places the tables of
+// each tenant in a schema named after the tenant (acme.depot).
+func TenantSchema(tenant, tn string) string {
+	return tenant + "." + tn
+}
+
+// TenantPrefix is a TenantResolver that prefixes the table names with the
+// name of the tenant (acme_depot).
+func TenantPrefix(tenant, tn string) string {
+	return tenant + "_" + tn
+}
+
+// SetTenantResolver sets the hook used to route the tables of the tenant
+// of a handle obtained via ForTenant.  A nil resolver selects TenantSchema.
+func (bf *BaseFlavor) SetTenantResolver(r TenantResolver) {
+	bf.tenantResolver = r
+}
+
+// GetTenant returns the tenant of a handle obtained via ForTenant.
+func (bf *BaseFlavor) GetTenant() string {
+	return bf.tenant
+}
+
+// ForTenant returns a handle sharing the db-connection of bf, whose table
+// names are routed to the tables of tenant.  Flavors provide their own
+// version.
+func (bf *BaseFlavor) ForTenant(tenant string) PublicDB {
+	log.Printf("method ForTenant has not been implemented for %s\n", bf.GetDBDriverName())
+	return nil
+}
+
+// tenantTableName applies the tenant resolver to table name tn.  Table
+// names are left untouched on handles without a tenant.
+func (bf *BaseFlavor) tenantTableName(tn string) string {
+
+	if bf.tenant == "" || tn == "" {
+		return tn
+	}
+	r := bf.tenantResolver
+	if r == nil {
+		r = TenantSchema
+	}
+	return r(bf.tenant, tn)
+}
+
+// isQualified reports whether table name tn is schema-qualified.
+func isQualified(tn string) bool {
+	return strings.Contains(tn, ".")
+}
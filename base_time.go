@@ -0,0 +1,127 @@
+package sqac
+
+import (
+	"reflect"
+	"time"
+)
+
+// TimeStore determines how time.Time values are written to the db.
+type TimeStore int
+
+const (
+	// TimeStoreUTC converts times to UTC before they are written.  This
+	// is the default.
+	TimeStoreUTC TimeStore = iota
+
+	// TimeStoreAsIs writes times in their own location.  The dbs without
+	// a zone-aware time type (mysql, sqlite, mssql and hdb) retain the
+	// wall-clock time only.
+	TimeStoreAsIs
+)
+
+// String returns the name of the time store for logging purposes.
+func (s TimeStore) String() string {
+
+	switch s {
+	case TimeStoreUTC:
+		return "utc"
+	case TimeStoreAsIs:
+		return "as-is"
+	default:
+		return "unknown"
+	}
+}
+
+// TimePolicy determines the handling of the time.Time and *time.Time
+// fields of the models.  Store applies to the times written by Create
+// and Update, and Location to the times read back by Create, Update,
+// GetEntity and the GetEntities methods.  A nil Location reads times
+// in UTC.  The zero-value policy stores and reads times in UTC.
+type TimePolicy struct {
+	Store    TimeStore
+	Location *time.Location
+}
+
+// SetTimePolicy sets the policy applied to the time fields of the models.
+func (bf *BaseFlavor) SetTimePolicy(p TimePolicy) {
+	bf.timePolicy = p
+}
+
+// GetTimePolicy reports the policy applied to the time fields of the models.
+func (bf *BaseFlavor) GetTimePolicy() TimePolicy {
+	return bf.timePolicy
+}
+
+// storeTime returns time t as it is to be written to the db.
+func (bf *BaseFlavor) storeTime(t time.Time) time.Time {
+
+	if bf.timePolicy.Store == TimeStoreUTC {
+		return t.UTC()
+	}
+	return t
+}
+
+// readTimes moves the time fields of the struct pointed to by ent to the
+// location of the time policy.  Embedded structs are processed as well.
+func (bf *BaseFlavor) readTimes(ent interface{}) {
+	readTimes(reflect.Indirect(reflect.ValueOf(ent)), bf.readTime)
+}
+
+// readTime returns time t read from the db in the location of the time
+// policy.  The dbs without a zone-aware time type retain the wall-clock
+// time only, which their drivers may hand back in a location of their own
+// (mysql with loc=Local for example).  The wall-clock time read from these
+// dbs is taken to be in UTC, or in the location of the time policy for
+// times stored as-is.
+func (bf *BaseFlavor) readTime(t time.Time) time.Time {
+
+	loc := bf.timePolicy.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	switch bf.GetDBDriverName() {
+	case "postgres":
+		return t.In(loc)
+	case "sqlite3":
+		// go-sqlite3 reads the wall-clock time as UTC before moving it
+		// to the location of its _loc option
+		t = t.UTC()
+	}
+	if t.IsZero() {
+		return t.In(loc)
+	}
+
+	wall := time.UTC
+	if bf.timePolicy.Store == TimeStoreAsIs {
+		wall = loc
+	}
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), wall)
+	return t.In(loc)
+}
+
+// readTimes sets the time fields of struct value v to the result of fn.
+func readTimes(v reflect.Value, fn func(time.Time) time.Time) {
+
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+		switch t := f.Interface().(type) {
+		case time.Time:
+			f.Set(reflect.ValueOf(fn(t)))
+		case *time.Time:
+			if t != nil {
+				*t = fn(*t)
+			}
+		default:
+			if v.Type().Field(i).Anonymous {
+				readTimes(reflect.Indirect(f), fn)
+			}
+		}
+	}
+}
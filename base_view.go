@@ -0,0 +1,119 @@
+package sqac
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/1414C/sqac/common"
+)
+
+// viewDDL is implemented by the flavors in order to create and drop views.
+type viewDDL interface {
+	createViewSQL(vn, query string) []string
+	ExistsView(vn string) bool
+}
+
+// ensure that the flavors are able to manage views
+var (
+	_ viewDDL = &PostgresFlavor{}
+	_ viewDDL = &MySQLFlavor{}
+	_ viewDDL = &SQLiteFlavor{}
+	_ viewDDL = &MSSQLFlavor{}
+	_ viewDDL = &HDBFlavor{}
+)
+
+// ExistsView checks the connected db for the presence of view vn.
+func (bf *BaseFlavor) ExistsView(vn string) bool {
+
+	n := 0
+	qs := "SELECT COUNT(*) FROM INFORMATION_SCHEMA.VIEWS WHERE table_schema = ? AND table_name = ?;"
+	dbName, vn := bf.splitSchema(vn)
+
+	bf.QsLog(qs, dbName, vn)
+	bf.db.QueryRow(qs, dbName, vn).Scan(&n)
+	return n > 0
+}
+
+// DropView drops view vn on the connected db if it exists.
+func (bf *BaseFlavor) DropView(vn string) error {
+	return bf.dropView(bf, vn)
+}
+
+// createViewSQL returns the statements creating or replacing view vn,
+// defined by SELECT statement query.
+func (bf *BaseFlavor) createViewSQL(vn, query string) []string {
+	return []string{"CREATE OR REPLACE VIEW " + vn + " AS " + query + ";"}
+}
+
+// splitViews splits the models in i into the models describing tables and
+// the models describing views.
+func splitViews(i []interface{}) ([]interface{}, []interface{}) {
+
+	tables := make([]interface{}, 0, len(i))
+	views := make([]interface{}, 0)
+	for _, ent := range i {
+		if common.IsView(ent) {
+			views = append(views, ent)
+			continue
+		}
+		tables = append(tables, ent)
+	}
+	return tables, views
+}
+
+// createViews creates or replaces the views described by the models in i
+// via r.  Views are created after the tables, so that they are able to
+// select from the tables created alongside them.
+func (bf *BaseFlavor) createViews(r viewDDL, i ...interface{}) error {
+
+	for _, ent := range i {
+		vn := bf.TableName(ent)
+		if vn == "" {
+			return fmt.Errorf("unable to determine view name in createViews")
+		}
+		for _, s := range r.createViewSQL(vn, bf.viewQuery(vn, ent)) {
+			_, err := bf.Exec(s)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// viewTableRegexp matches the {{table:<name>}} placeholders of a view query.
+var viewTableRegexp = regexp.MustCompile(`\{\{table:\s*([A-Za-z_][A-Za-z0-9_$#.]*)\s*\}\}`)
+
+// viewQuery returns the SELECT statement defining view vn described by
+// ent.  The {{table:<name>}} placeholders of the query are replaced by the
+// name of the table, routed to the tenant of the handle and qualified by
+// the schema of the view.  The rest of the query is used as-is.
+func (bf *BaseFlavor) viewQuery(vn string, ent interface{}) string {
+
+	return viewTableRegexp.ReplaceAllStringFunc(common.GetViewQuery(ent), func(m string) string {
+		tn := viewTableRegexp.FindStringSubmatch(m)[1]
+		return qualifiedName(vn, bf.tenantTableName(tn))
+	})
+}
+
+// dropViews drops the views described by the models in i via r.
+func (bf *BaseFlavor) dropViews(r viewDDL, i ...interface{}) error {
+
+	for _, ent := range i {
+		err := bf.dropView(r, bf.TableName(ent))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropView drops view vn via r if it exists.
+func (bf *BaseFlavor) dropView(r viewDDL, vn string) error {
+
+	if !r.ExistsView(vn) {
+		return nil
+	}
+	_, err := bf.Exec("DROP VIEW " + vn + ";")
+	return err
+}
@@ -0,0 +1,124 @@
+// Command sqacddl writes the DDL for a set of sqac models as a standalone
+// SQL script for the chosen db flavor, without connecting to a db.
+//
+// The models are read from a Go package of the calling module:
+//
+//	sqacddl -db postgres -pkg github.com/acme/app/models -o schema.sql Depot Warehouse
+//
+// sqacddl generates a small program importing the package, runs it with
+// 'go run' from within the current module and writes the output of
+// sqac.ExportDDL to the output file, or to stdout if -o is omitted.  The
+// models are listed in creation order, so referenced tables should be
+// named before the tables holding foreign-keys to them.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// identRegexp matches the exported type names of the models.
+var identRegexp = regexp.MustCompile(`^[A-Z][A-Za-z0-9_]*$`)
+
+func main() {
+
+	flavor := flag.String("db", "postgres", "db flavor: postgres, mysql, sqlite, mssql or hdb")
+	pkg := flag.String("pkg", "", "import path of the package holding the models")
+	out := flag.String("o", "", "output file; stdout if omitted")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: sqacddl -db <flavor> -pkg <import path> [-o <file>] Model...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *pkg == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := program(*flavor, *pkg, flag.Args())
+	if err != nil {
+		log.Fatalf("%s\n", err.Error())
+	}
+
+	script, err := run(src)
+	if err != nil {
+		log.Fatalf("%s\n", err.Error())
+	}
+
+	if *out == "" {
+		fmt.Print(script)
+		return
+	}
+	err = os.WriteFile(*out, []byte(script), 0644)
+	if err != nil {
+		log.Fatalf("%s\n", err.Error())
+	}
+}
+
+// program returns the source of the program exporting the DDL for the
+// named models of package pkg.
+func program(flavor, pkg string, models []string) (string, error) {
+
+	lits := make([]string, 0, len(models))
+	for _, m := range models {
+		if !identRegexp.MatchString(m) {
+			return "", fmt.Errorf("'%s' is not an exported type name", m)
+		}
+		lits = append(lits, "m."+m+"{}")
+	}
+
+	src := `package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/1414C/sqac"
+	m "` + pkg + `"
+)
+
+func main() {
+	script, err := sqac.ExportDDL(` + fmt.Sprintf("%q", flavor) + `, ` + strings.Join(lits, ", ") + `)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Print(script)
+}
+`
+	return src, nil
+}
+
+// run writes the program to a temporary directory in the current module,
+// runs it and returns its output.
+func run(src string) (string, error) {
+
+	dir, err := os.MkdirTemp(".", ".sqacddl-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	err = os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0644)
+	if err != nil {
+		return "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", "run", "./"+filepath.Base(dir))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("unable to export the DDL: %v\n%s", err, stderr.String())
+	}
+	return stdout.String(), nil
+}
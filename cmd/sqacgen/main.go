@@ -0,0 +1,56 @@
+// Command sqacgen reads the tables of an existing db and writes sqac-tagged
+// go models for them:
+//
+//	sqacgen -db postgres -cs "host=127.0.0.1 user=godev dbname=sqactst sslmode=disable" -pkg models -o models.go depot warehouse
+//
+// All tables of the db are read if no table names are provided.  The
+// models are written to the output file, or to stdout if -o is omitted.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/1414C/sqac"
+
+	_ "github.com/SAP/go-hdb/driver"
+	_ "github.com/denisenkom/go-mssqldb"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+
+	flavor := flag.String("db", "postgres", "db flavor: postgres, mysql, sqlite, mssql or hdb")
+	cs := flag.String("cs", "", "connection string of the db")
+	pkg := flag.String("pkg", "models", "package name of the generated file")
+	out := flag.String("o", "", "output file; stdout if omitted")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: sqacgen -db <flavor> -cs <connection string> [-pkg <name>] [-o <file>] [table...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *cs == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	handle := sqac.Create(*flavor, false, false, *cs)
+	src, err := handle.GenerateModels(*pkg, flag.Args()...)
+	if err != nil {
+		log.Fatalf("%s\n", err.Error())
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	err = os.WriteFile(*out, src, 0644)
+	if err != nil {
+		log.Fatalf("%s\n", err.Error())
+	}
+}
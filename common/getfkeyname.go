@@ -2,12 +2,14 @@ package common
 
 import (
 	"fmt"
+	"strings"
 )
 
 // GetFKeyName can be used to determine the foreign-key name based on a set
 // of input fields.  Note that this function does not guarantee or check
 // for the existence of the foreign-key; it simply provides the name that
-// would have been used for the given parameter values.
+// would have been used for the given parameter values.  Schema-qualified
+// table-names are reduced to their table-name.
 // i:  Model{}
 // ft: From Table
 // rt: Reference Table
@@ -24,6 +26,11 @@ func GetFKeyName(i interface{}, ft, rt, ff, rf string) (string, error) {
 		return "", fmt.Errorf("provide all required parameters for common.GetFKeyName: got ft: %s, rt: %s, ff: %s, rf: %s", ft, rt, ff, rf)
 	}
 
-	fkn := "fk_" + ft + "_" + rt + "_" + rf
+	fkn := "fk_" + bareName(ft) + "_" + bareName(rt) + "_" + rf
 	return fkn, nil
 }
+
+// bareName strips the schema from a schema-qualified table-name.
+func bareName(tn string) string {
+	return tn[strings.LastIndex(tn, ".")+1:]
+}
@@ -5,15 +5,124 @@ import (
 	"strings"
 )
 
-// GetTableName determines the db table-name based on interface{} i
+// TableNamer is implemented by models that name their db table explicitly.
+type TableNamer interface {
+	TableName() string
+}
+
+// TableSchemer is implemented by models that place their db table in a
+// named schema.
+type TableSchemer interface {
+	TableSchema() string
+}
+
+// TableNaming holds the options used to derive table-names from the go
+// type names of the models.  SnakeCase switches the derived names from
+// the default lower-case form (GetCmdTest -> getcmdtest) to snake_case
+// (GetCmdTest -> get_cmd_test), and Prefix is prepended to the derived
+// names.  Explicit table-names are used as-is.  The zero value selects
+// the default lower-case names without a prefix.
+type TableNaming struct {
+	SnakeCase bool
+	Prefix    string
+}
+
+// GetTableName determines the db table-name based on interface{} i using
+// the default TableNaming.  See TableNaming.TableName.
 func GetTableName(i interface{}) string {
+	return TableNaming{}.TableName(i)
+}
+
+// TableName determines the db table-name based on interface{} i.  i may
+// be a model, a pointer to a model or a (pointer to a) slice of models.
+// The table-name is taken from the first of:
+//
+//   - the TableName() method of the model
+//   - the table key of a struct-level tag: _ struct{} `sqac:"table:name"`
+//   - the go type name of the model, lower-cased or snake_cased and prefixed
+//     as per the options of n
+func (n TableNaming) TableName(i interface{}) string {
 
-	tn := reflect.TypeOf(i).String() // Profile{} for example
-	if strings.Contains(tn, ".") {
-		el := strings.Split(tn, ".")
-		tn = strings.ToLower(el[len(el)-1])
+	t := modelType(i)
+
+	if tnr, ok := reflect.New(t).Interface().(TableNamer); ok {
+		if tn := tnr.TableName(); tn != "" {
+			return tn
+		}
+	}
+
+	if tn := tableTagValue(t, "table"); tn != "" {
+		return tn
+	}
+
+	tn := t.Name()
+	if tn == "" {
+		tn = t.String()
+		if strings.Contains(tn, ".") {
+			el := strings.Split(tn, ".")
+			tn = el[len(el)-1]
+		}
+	}
+	if n.SnakeCase {
+		tn = CamelToSnake(tn)
 	} else {
 		tn = strings.ToLower(tn)
 	}
-	return tn
+	return n.Prefix + tn
+}
+
+// GetTableRenamedFrom returns the previous table-name of the model held in
+// interface{} i as per the renamed_from key of its struct-level tag:
+// _ struct{} `sqac:"table:name;renamed_from:old_name"`.  An empty string
+// is returned if the model carries no renamed_from hint.
+func GetTableRenamedFrom(i interface{}) string {
+	return tableTagValue(modelType(i), "renamed_from")
+}
+
+// GetTableSchema returns the schema of the table of the model held in
+// interface{} i as per its TableSchema() method, or the schema key of its
+// struct-level tag: _ struct{} `sqac:"schema:name"`.  An empty string is
+// returned if the model does not name a schema.
+func GetTableSchema(i interface{}) string {
+
+	t := modelType(i)
+	if tsr, ok := reflect.New(t).Interface().(TableSchemer); ok {
+		if sn := tsr.TableSchema(); sn != "" {
+			return sn
+		}
+	}
+	return tableTagValue(t, "schema")
+}
+
+// modelType returns the struct type underlying interface{} i by way of
+// any number of pointer and slice types.
+func modelType(i interface{}) reflect.Type {
+
+	t := reflect.TypeOf(i)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t
+}
+
+// tableTagValue returns the value of key k in the sqac tag of the blank
+// struct-level field of struct type t.
+func tableTagValue(t reflect.Type, k string) string {
+
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Name != "_" {
+			continue
+		}
+		for _, p := range strings.Split(f.Tag.Get("sqac"), ";") {
+			kv := strings.SplitN(strings.TrimSpace(p), ":", 2)
+			if len(kv) == 2 && strings.TrimSpace(kv[0]) == k {
+				return strings.TrimSpace(kv[1])
+			}
+		}
+	}
+	return ""
 }
@@ -0,0 +1,47 @@
+package common
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ViewQueryer is implemented by models that describe a db view rather than
+// a table.  ViewQuery returns the SELECT statement defining the view.  The
+// tables selected from may be given as {{table:<name>}} placeholders, which
+// are routed to the tables of the tenant of the handle creating the view:
+//
+//	SELECT id, name FROM {{table:pier}} WHERE length >= 100
+type ViewQueryer interface {
+	ViewQuery() string
+}
+
+// viewQueries holds the view definitions registered via RegisterView.
+var viewQueries = make(map[reflect.Type]string)
+
+// RegisterView declares the model of interface{} i to describe a db view
+// defined by SELECT statement query.  This allows views to be defined for
+// models that do not implement ViewQueryer.  RegisterView is meant to be
+// called during initialisation.
+func RegisterView(i interface{}, query string) {
+	viewQueries[modelType(i)] = query
+}
+
+// GetViewQuery returns the SELECT statement defining the view described by
+// interface{} i, taken from the ViewQuery() method of the model or from the
+// query registered via RegisterView.  An empty string is returned if the
+// model describes a table.  A trailing ';' is removed from the statement.
+func GetViewQuery(i interface{}) string {
+
+	t := modelType(i)
+
+	q := viewQueries[t]
+	if vqr, ok := reflect.New(t).Interface().(ViewQueryer); ok {
+		q = vqr.ViewQuery()
+	}
+	return strings.TrimSuffix(strings.TrimSpace(q), ";")
+}
+
+// IsView reports whether interface{} i describes a db view.
+func IsView(i interface{}) bool {
+	return GetViewQuery(i) != ""
+}
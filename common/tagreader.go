@@ -1,11 +1,88 @@
 package common
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"reflect"
 	"strings"
 )
 
+// ValuerType is the UnderGoType of fields whose go type implements
+// driver.Valuer or sql.Scanner.  Such fields are mapped onto a single
+// column, with the db type taken from the type key of the sqac tag.
+const ValuerType = "driver.Valuer"
+
+// JSONType is the UnderGoType of fields whose go type implements
+// JSONColumner, such as sqac.JSON.  Such fields are stored in a json
+// column.
+const JSONType = "json"
+
+// DecimalType is the UnderGoType of fields whose go type implements
+// DecimalColumner, such as sqac.Decimal.  Such fields are stored in a
+// decimal(p,s) column.
+const DecimalType = "decimal"
+
+// DecimalColumner is implemented by go types that are stored as exact
+// decimal numbers.  The types are expected to implement driver.Valuer
+// and sql.Scanner as well.
+type DecimalColumner interface {
+	DecimalColumn()
+}
+
+// ArrayType is the UnderGoType of fields whose go type implements
+// ArrayColumner, such as sqac.Array, and of plain slices of scalars such
+// as []string (see IsScalarSlice).  Such fields are stored in a native
+// array column on postgres, and in a json column otherwise.
+const ArrayType = "array"
+
+// ArrayColumner is implemented by slice types that are stored as arrays.
+// The types are expected to implement sql.Scanner as well.
+type ArrayColumner interface {
+	ArrayColumn()
+}
+
+// DateType is the UnderGoType of fields whose go type implements
+// DateColumner, such as sqac.Date.  Such fields are stored in a date
+// column.
+const DateType = "date"
+
+// DateColumner is implemented by go types that are stored as calendar
+// dates.  The types are expected to implement driver.Valuer and
+// sql.Scanner as well.
+type DateColumner interface {
+	DateColumn()
+}
+
+// TimeOfDayType is the UnderGoType of fields whose go type implements
+// TimeOfDayColumner, such as sqac.TimeOfDay.  Such fields are stored in a
+// time column.
+const TimeOfDayType = "timeofday"
+
+// TimeOfDayColumner is implemented by go types that are stored as times
+// of day.  The types are expected to implement driver.Valuer and
+// sql.Scanner as well.
+type TimeOfDayColumner interface {
+	TimeOfDayColumn()
+}
+
+// JSONColumner is implemented by go types that are stored as json
+// documents.  The types are expected to implement driver.Valuer and
+// sql.Scanner as well.
+type JSONColumner interface {
+	JSONColumn()
+}
+
+var (
+	valuerIface  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scannerIface = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	jsonIface    = reflect.TypeOf((*JSONColumner)(nil)).Elem()
+	decimalIface = reflect.TypeOf((*DecimalColumner)(nil)).Elem()
+	arrayIface   = reflect.TypeOf((*ArrayColumner)(nil)).Elem()
+	dateIface    = reflect.TypeOf((*DateColumner)(nil)).Elem()
+	todIface     = reflect.TypeOf((*TimeOfDayColumner)(nil)).Elem()
+)
+
 // SqacPair holds name-value-pairs for db field attributes
 type SqacPair struct {
 	Name  string
@@ -38,10 +115,52 @@ func TagReader(i interface{}, t reflect.Type) (fd []FieldDef, err error) {
 
 	for i := 0; i < t.NumField(); i++ {
 
+		// the blank field carries the struct-level tag of the model
+		// (see GetTableName); it is kept as a non-persistent field so
+		// that the field definitions line up with the struct fields.
+		if t.Field(i).Name == "_" {
+			fd = append(fd, FieldDef{FName: "_", GoName: "_", GoType: t.Field(i).Type.String(), NoDB: true})
+			continue
+		}
+
 		// dealing with a basic field, or an embedded struct?
 		// get the field-type as a string
 		fts := t.Field(i).Type.String()
 		ftu := strings.TrimPrefix(fts, "*")
+		if ftu == "[]uint8" {
+			ftu = "[]byte"
+		}
+		if isValuer(t.Field(i).Type) {
+			ftu = ValuerType
+			if implements(t.Field(i).Type, jsonIface) {
+				ftu = JSONType
+			}
+			if implements(t.Field(i).Type, decimalIface) {
+				ftu = DecimalType
+			}
+			if implements(t.Field(i).Type, arrayIface) {
+				ftu = ArrayType
+			}
+			if implements(t.Field(i).Type, dateIface) {
+				ftu = DateType
+			}
+			if implements(t.Field(i).Type, todIface) {
+				ftu = TimeOfDayType
+			}
+		}
+
+		// plain slices of scalars are stored like sqac.Array; slices of
+		// other element types are not supported, apart from []byte
+		if IsScalarSlice(t.Field(i).Type) {
+			ftu = ArrayType
+		}
+		ft := t.Field(i).Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Slice && ftu != "[]byte" && ftu != ArrayType && !isValuer(t.Field(i).Type) {
+			return nil, fmt.Errorf("field %s of type %s is not supported; only slices of string, int, int32, int64, float32, float64 and bool are stored as arrays", t.Field(i).Name, fts)
+		}
 
 		// this would be cleaner for embedded structs, but time.Time is a struct etc..
 		// if t.Field(i).Type.Kind() == reflect.Struct {
@@ -49,7 +168,8 @@ func TagReader(i interface{}, t reflect.Type) (fd []FieldDef, err error) {
 		if ftu != "uint" && ftu != "uint8" && ftu != "uint16" && ftu != "uint32" && ftu != "uint64" &&
 			ftu != "int" && ftu != "int8" && ftu != "int16" && ftu != "int32" && ftu != "int64" &&
 			ftu != "rune" && ftu != "byte" && ftu != "string" && ftu != "float32" && ftu != "float64" &&
-			ftu != "bool" && ftu != "time.Time" {
+			ftu != "bool" && ftu != "time.Time" && ftu != "[]byte" && ftu != ValuerType && ftu != JSONType && ftu != DecimalType && ftu != ArrayType &&
+			ftu != DateType && ftu != TimeOfDayType {
 
 			// embedded struct - recurse and append resulting field defs
 			// get the Value from the StructField (t.Field(i))
@@ -68,7 +188,7 @@ func TagReader(i interface{}, t reflect.Type) (fd []FieldDef, err error) {
 			// recursively call the TagReader(interface{}, reflect.Type)
 			es, err := TagReader(fv.Interface(), ftr)
 			if err != nil {
-				return nil, fmt.Errorf("unable to parse embedded struct of %s %s", fv.Type(), fv.Interface())
+				return nil, fmt.Errorf("unable to parse embedded struct of %s %s: %v", fv.Type(), fv.Interface(), err)
 			}
 			fd = append(fd, es...)
 			continue
@@ -91,7 +211,9 @@ func TagReader(i interface{}, t reflect.Type) (fd []FieldDef, err error) {
 		if sqacTag != "" {
 			sqacTags := strings.Split(sqacTag, ";")
 			for k := range sqacTags {
-				sqacVars := strings.Split(sqacTags[k], ":")
+				// split on the first ':' only, so that values such as
+				// check-constraint expressions may contain a ':'
+				sqacVars := strings.SplitN(sqacTags[k], ":", 2)
 				switch len(sqacVars) {
 				case 2:
 					p := SqacPair{
@@ -114,3 +236,51 @@ func TagReader(i interface{}, t reflect.Type) (fd []FieldDef, err error) {
 	}
 	return fd, nil
 }
+
+// TagValue returns the value of key name in the sqac tag of the field, or
+// an empty string if the tag does not hold the key.
+func (fd FieldDef) TagValue(name string) string {
+
+	for _, p := range fd.SqacPairs {
+		if p.Name == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// implements reports whether go type t or its pointer implements
+// interface iface.
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
+
+// IsScalarSlice reports whether go type t is a plain slice of one of the
+// element types of sqac.Array, such as []string or []int64.
+func IsScalarSlice(t reflect.Type) bool {
+
+	if t.Kind() != reflect.Slice || t.Name() != "" || isValuer(t) {
+		return false
+	}
+	et := t.Elem()
+	if et.PkgPath() != "" {
+		return false
+	}
+	switch et.Kind() {
+	case reflect.String, reflect.Int, reflect.Int64, reflect.Int32, reflect.Float64, reflect.Float32, reflect.Bool:
+		return true
+	}
+	return false
+}
+
+// isValuer reports whether go type t (or the type it points to) handles
+// its own conversion to and from db values, by implementing driver.Valuer
+// or sql.Scanner.
+func isValuer(t reflect.Type) bool {
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Implements(valuerIface) || reflect.PtrTo(t).Implements(valuerIface) ||
+		reflect.PtrTo(t).Implements(scannerIface)
+}
@@ -0,0 +1,286 @@
+package sqac_test
+
+import (
+	"testing"
+
+	"github.com/1414C/sqac/common"
+)
+
+// TestCreateGroupedConstraintsFromModel
+//
+// Create table shelf via CreateTables(i ...interface{})
+// with a grouped unique constraint and a check
+// constraint based on model attributes.
+func TestCreateGroupedConstraintsFromModel(t *testing.T) {
+
+	type Shelf struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		Aisle  string `db:"aisle" sqac:"nullable:false;constraint:uq_shelf_aisle_bay"`
+		Bay    int    `db:"bay" sqac:"nullable:false;constraint:uq_shelf_aisle_bay"`
+		Weight int    `db:"weight" sqac:"nullable:false;default:0;check:weight >= 0"`
+	}
+
+	err := Handle.DropTables(Shelf{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	err = Handle.CreateTables(Shelf{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	// determine the table name as per the table creation logic
+	tn := common.GetTableName(Shelf{})
+
+	if !Handle.ExistsConstraint(tn, "uq_shelf_aisle_bay") {
+		t.Errorf("expected constraint uq_shelf_aisle_bay on table %s - got none", tn)
+	}
+
+	if !Handle.ExistsConstraint(tn, "ck_shelf_weight") {
+		t.Errorf("expected constraint ck_shelf_weight on table %s - got none", tn)
+	}
+
+	shelf := Shelf{Aisle: "A", Bay: 1, Weight: 10}
+	err = Handle.Create(&shelf)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	// same aisle in a different bay is fine
+	shelf = Shelf{Aisle: "A", Bay: 2, Weight: 10}
+	err = Handle.Create(&shelf)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	// duplicate aisle / bay combination must be rejected
+	shelf = Shelf{Aisle: "A", Bay: 1, Weight: 10}
+	err = Handle.Create(&shelf)
+	if err == nil {
+		t.Errorf("expected a unique constraint violation for aisle A bay 1 - got none")
+	}
+
+	// negative weight must be rejected by the check constraint
+	shelf = Shelf{Aisle: "B", Bay: 1, Weight: -1}
+	err = Handle.Create(&shelf)
+	if err == nil {
+		t.Errorf("expected a check constraint violation for weight -1 - got none")
+	}
+
+	err = Handle.DropTables(Shelf{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+}
+
+// TestAlterTablesAddConstraints
+//
+// Create table bin without constraints, then
+// add a grouped unique constraint and a check
+// constraint via AlterTables(i ...interface{}).
+func TestAlterTablesAddConstraints(t *testing.T) {
+
+	tn := ""
+
+	{
+		type Bin struct {
+			ID    uint64 `db:"id" sqac:"primary_key:inc"`
+			Row   string `db:"row" sqac:"nullable:false;default:A"`
+			Level int    `db:"level" sqac:"nullable:false;default:0"`
+		}
+
+		err := Handle.DropTables(Bin{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+
+		err = Handle.CreateTables(Bin{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		tn = common.GetTableName(Bin{})
+
+		bin := Bin{Row: "A", Level: 1}
+		err = Handle.Create(&bin)
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+	}
+
+	if Handle.ExistsConstraint(tn, "uq_bin_row_level") {
+		t.Errorf("constraint uq_bin_row_level was not expected to exist on table %s", tn)
+	}
+
+	{
+		type Bin struct {
+			ID    uint64 `db:"id" sqac:"primary_key:inc"`
+			Row   string `db:"row" sqac:"nullable:false;default:A;constraint:uq_bin_row_level"`
+			Level int    `db:"level" sqac:"nullable:false;default:0;constraint:uq_bin_row_level;check:level < 100"`
+		}
+
+		err := Handle.AlterTables(Bin{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+
+		if !Handle.ExistsConstraint(tn, "uq_bin_row_level") {
+			t.Errorf("expected constraint uq_bin_row_level on table %s - got none", tn)
+		}
+
+		if !Handle.ExistsConstraint(tn, "ck_bin_level") {
+			t.Errorf("expected constraint ck_bin_level on table %s - got none", tn)
+		}
+
+		// existing data must survive the alteration
+		var bins []Bin
+		count, err := Handle.GetEntitiesCP(&bins, nil, nil)
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		if count != 1 {
+			t.Errorf("expected 1 row in table %s following AlterTables - got %d", tn, count)
+		}
+
+		bin := Bin{Row: "A", Level: 1}
+		err = Handle.Create(&bin)
+		if err == nil {
+			t.Errorf("expected a unique constraint violation for row A level 1 - got none")
+		}
+
+		err = Handle.DropTables(Bin{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+	}
+}
+
+// TestAlterCheckConstraint
+//
+// Change the expression of a check constraint and check
+// that AlterTables re-creates the constraint.
+func TestAlterCheckConstraint(t *testing.T) {
+
+	tn := ""
+	{
+		type Carton struct {
+			ID     uint64 `db:"id" sqac:"primary_key:inc"`
+			Weight int    `db:"weight" sqac:"nullable:false;default:0;check:weight < 100"`
+		}
+
+		err := Handle.DropTables(Carton{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		err = Handle.CreateTables(Carton{})
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		tn = common.GetTableName(Carton{})
+
+		plan, err := Handle.PlanAlterTables(Carton{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		if len(plan) != 0 {
+			t.Errorf("expected no changes to table %s - got %v", tn, plan)
+		}
+
+		c := Carton{Weight: 50}
+		err = Handle.Create(&c)
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+	}
+
+	type Carton struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		Weight int    `db:"weight" sqac:"nullable:false;default:0;check:weight < 1000"`
+	}
+	defer Handle.DropTables(Carton{})
+
+	plan, err := Handle.PlanAlterTables(Carton{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) == 0 {
+		t.Errorf("expected the changed check constraint of table %s in the alter plan - got none", tn)
+	}
+
+	err = Handle.AlterTables(Carton{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	c := Carton{Weight: 500}
+	err = Handle.Create(&c)
+	if err != nil {
+		t.Errorf("expected weight 500 to pass the altered check constraint - got %s", err.Error())
+	}
+
+	plan, err = Handle.PlanAlterTables(Carton{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to table %s following AlterTables - got %v", tn, plan)
+	}
+}
+
+// TestAlterCheckConstraintOrder
+//
+// Swap the operands of a check constraint expression and
+// check that AlterTables re-creates the constraint.
+func TestAlterCheckConstraintOrder(t *testing.T) {
+
+	{
+		type Span struct {
+			ID uint64 `db:"id" sqac:"primary_key:inc"`
+			Lo int    `db:"lo" sqac:"nullable:false;default:0"`
+			Hi int    `db:"hi" sqac:"nullable:false;default:0;check:lo < hi"`
+		}
+
+		err := Handle.DropTables(Span{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		err = Handle.CreateTables(Span{})
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+
+		plan, err := Handle.PlanAlterTables(Span{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		if len(plan) != 0 {
+			t.Errorf("expected no changes to table span - got %v", plan)
+		}
+	}
+
+	type Span struct {
+		ID uint64 `db:"id" sqac:"primary_key:inc"`
+		Lo int    `db:"lo" sqac:"nullable:false;default:0"`
+		Hi int    `db:"hi" sqac:"nullable:false;default:0;check:hi < lo"`
+	}
+	defer Handle.DropTables(Span{})
+
+	plan, err := Handle.PlanAlterTables(Span{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) == 0 {
+		t.Errorf("expected the reordered check constraint of table span in the alter plan - got none")
+	}
+
+	err = Handle.AlterTables(Span{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	s := Span{Lo: 5, Hi: 1}
+	err = Handle.Create(&s)
+	if err != nil {
+		t.Errorf("expected lo 5 and hi 1 to pass the altered check constraint - got %s", err.Error())
+	}
+}
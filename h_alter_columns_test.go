@@ -0,0 +1,134 @@
+package sqac_test
+
+import (
+	"testing"
+
+	"github.com/1414C/sqac"
+	"github.com/1414C/sqac/common"
+)
+
+// TestAlterTablesColumnChanges
+//
+// Create table carton, then change the type, nullability
+// and default of existing columns and remove a field from
+// the model.  Check that AlterTables applies the column
+// changes and that the removed column is dealt with as per
+// the destructive-change policy.
+func TestAlterTablesColumnChanges(t *testing.T) {
+
+	tn := ""
+
+	{
+		type Carton struct {
+			ID     uint64 `db:"id" sqac:"primary_key:inc"`
+			Label  string `db:"label" sqac:"nullable:true"`
+			Qty    int    `db:"qty" sqac:"nullable:false;default:0"`
+			Remark string `db:"remark" sqac:"nullable:true"`
+		}
+
+		err := Handle.DropTables(Carton{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+
+		err = Handle.CreateTables(Carton{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		tn = common.GetTableName(Carton{})
+
+		carton := Carton{Label: "first", Qty: 1, Remark: "keep me"}
+		err = Handle.Create(&carton)
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+	}
+
+	type Carton struct {
+		ID    uint64 `db:"id" sqac:"primary_key:inc"`
+		Label string `db:"label" sqac:"nullable:false;default:unlabelled"`
+		Qty   int64  `db:"qty" sqac:"nullable:false;default:5"`
+	}
+
+	// the default policy skips the dropping of column remark
+	if Handle.GetDestructivePolicy() != sqac.DestructiveSkip {
+		t.Errorf("expected default destructive policy %v - got %v", sqac.DestructiveSkip, Handle.GetDestructivePolicy())
+	}
+
+	err := Handle.AlterTables(Carton{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	if !Handle.ExistsColumn(tn, "remark") {
+		t.Errorf("column remark was expected to be retained on table %s", tn)
+	}
+
+	// existing data must survive the alteration.  the retained column
+	// remark is not part of the model, so the rows are counted directly.
+	count := 0
+	err = Handle.Get(&count, "SELECT COUNT(*) FROM "+tn)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if count != 1 {
+		t.Errorf("expected 1 row in table %s following AlterTables - got %d", tn, count)
+	}
+
+	// new default for qty
+	_, err = Handle.Exec("INSERT INTO " + tn + " (label) VALUES ('second')")
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	// label is no longer nullable
+	_, err = Handle.Exec("INSERT INTO " + tn + " (label, qty) VALUES (NULL, 1)")
+	if err == nil {
+		t.Errorf("expected a not null violation on column label of table %s - got none", tn)
+	}
+
+	var qty int64
+	err = Handle.Get(&qty, "SELECT qty FROM "+tn+" WHERE label = 'second'")
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if qty != 5 {
+		t.Errorf("expected default qty 5 in table %s - got %d", tn, qty)
+	}
+
+	// the error policy refuses to alter the table
+	Handle.SetDestructivePolicy(sqac.DestructiveError)
+	err = Handle.AlterTables(Carton{})
+	if err == nil {
+		t.Errorf("expected a destructive change error for table %s - got none", tn)
+	}
+	if !Handle.ExistsColumn(tn, "remark") {
+		t.Errorf("column remark was expected to be retained on table %s", tn)
+	}
+
+	// the apply policy drops column remark
+	Handle.SetDestructivePolicy(sqac.DestructiveApply)
+	err = Handle.AlterTables(Carton{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if Handle.ExistsColumn(tn, "remark") {
+		t.Errorf("column remark was expected to be dropped from table %s", tn)
+	}
+	Handle.SetDestructivePolicy(sqac.DestructiveSkip)
+
+	// with column remark gone, the table can be read via the model again
+	var cartons []Carton
+	u64, err := Handle.GetEntitiesCP(&cartons, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if u64 != 2 {
+		t.Errorf("expected 2 rows in table %s following AlterTables - got %d", tn, u64)
+	}
+
+	err = Handle.DropTables(Carton{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+}
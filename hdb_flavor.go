@@ -2,14 +2,18 @@ package sqac
 
 import (
 	"bytes"
+	"database/sql"
+	_ "embed"
 	"fmt"
 	"log"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/1414C/sqac/common"
+	"github.com/jmoiron/sqlx"
 )
 
 // HDBFlavor is a SAP Hana-specific implementation, where
@@ -27,6 +31,11 @@ import (
 type HDBFlavor struct {
 	BaseFlavor
 
+	// insertSPs records whether the insert procedures of the tables
+	// exist, keyed by procedure name.  It is shared by the tenant
+	// handles of the handle.
+	insertSPs *sync.Map
+
 	//================================================================
 	// possible local HDB-specific overrides
 	//================================================================
@@ -228,6 +237,12 @@ type HDBFlavor struct {
 // Sys.Index_Columns
 //
 
+// insertSPTemplate is the template of the insert procedure generated for
+// the tables with an auto-incrementing key.
+//
+//go:embed templates/createInsertSP.gotmpl
+var insertSPTemplate string
+
 type hdbSeqTyp struct {
 	TableName string
 	FieldName string
@@ -261,6 +276,12 @@ func (hf *HDBFlavor) createTables(calledFromAlter bool, i ...interface{}) ([]For
 	// get the list of table Model{}s
 	di := i[0].([]interface{})
 
+	// create the schemas of the tables if need be
+	err := hf.createTableSchemas(hf, di...)
+	if err != nil {
+		return nil, err
+	}
+
 	for t, ent := range di {
 
 		ftr := reflect.TypeOf(ent)
@@ -269,14 +290,15 @@ func (hf *HDBFlavor) createTables(calledFromAlter bool, i ...interface{}) ([]For
 		}
 
 		// determine the table name
-		tn := common.GetTableName(di[t])
+		tn := hf.TableName(di[t])
 		if tn == "" {
 			return nil, fmt.Errorf("unable to determine table name in hf.CreateTables")
 		}
 
 		// if the table is found to exist, skip the creation
-		// and move on to the next table in the list.
-		if hf.ExistsTable(tn) {
+		// and move on to the next table in the list.  tables
+		// dropped earlier in a plan are considered absent.
+		if !hf.absentInPlan(tn) && hf.ExistsTable(tn) {
 			if hf.log {
 				log.Printf("CreateTable - table %s exists - skipping...\n", tn)
 			}
@@ -288,7 +310,7 @@ func (hf *HDBFlavor) createTables(calledFromAlter bool, i ...interface{}) ([]For
 		hf.QsLog(tc.tblSchema)
 
 		// create the table on the db
-		hf.db.MustExec(tc.tblSchema)
+		hf.mustExec(tc.tblSchema)
 
 		// deal with the auto-incrementing by creating sequence manually
 		for _, sq := range tc.seq {
@@ -314,9 +336,15 @@ func (hf *HDBFlavor) createTables(calledFromAlter bool, i ...interface{}) ([]For
 			hf.CreateSequence(seqDef.SeqName, seqDef.Start)
 		}
 
+		// create the insert procedure used by Create
+		err = hf.createInsertSP(tn, tc)
+		if err != nil {
+			return nil, err
+		}
+
 		// create the table indices
-		for k, in := range tc.ind {
-			hf.CreateIndex(k, in)
+		for _, k := range indexNames(tc.ind) {
+			hf.CreateIndex(k, tc.ind[k])
 		}
 
 		// add foreign-key information to the buffer
@@ -364,8 +392,10 @@ func (hf *HDBFlavor) buildTablSchema(tn string, ent interface{}) TblComponents {
 	var hdbSeq hdbSeqTyp
 
 	indexes := make(map[string]IndexInfo)
+	constraints := make(map[string]ConstraintInfo)
+	columns := make([]ColComponents, 0)
 	fKeys := make([]FKeyInfo, 0)
-	tableSchema := "CREATE COLUMN TABLE " + qt + tn + qt + " ("
+	tableSchema := "CREATE COLUMN TABLE " + quotedTableName(tn, qt) + " ("
 
 	// get a list of the field names, go-types and db attributes.
 	// TagReader is a common function across db-flavors. For
@@ -421,15 +451,37 @@ func (hf *HDBFlavor) buildTablSchema(tn string, ent interface{}) TblComponents {
 
 		case "string":
 			col.fType = "nvarchar(255)" //
+			if fd.TagValue("primary_key") == "uuid" {
+				col.fType = "nvarchar(36)"
+			}
+
+		case common.DecimalType:
+			col.fType = decimalType("decimal", fd)
+
+		case common.JSONType, common.ArrayType:
+			col.fType = "nclob"
+
+		case common.ValuerType:
+			col.fType = "nvarchar(255)"
+
+		case common.DateType:
+			col.fType = "date"
+
+		case common.TimeOfDayType:
+			col.fType = "time"
 
 		case "time.Time":
 			col.fType = "timestamp"
 
+		case "[]byte":
+			col.fType = "blob"
+
 		default:
 			err := fmt.Errorf("go type %s is not presently supported", fldef[idx].FType)
 			panic(err)
 		}
 		fldef[idx].FType = col.fType
+		col.uType = userType(fd, "nvarchar")
 
 		// read sqac tag pairs and apply
 		// seqName := ""
@@ -446,15 +498,10 @@ func (hf *HDBFlavor) buildTablSchema(tn string, ent interface{}) TblComponents {
 					if p.Value == "inc" {
 						hdbSeq.TableName = strings.ToUpper(tn)
 						hdbSeq.FieldName = strings.ToUpper(fd.FName)
-						hdbSeq.SeqName = "SEQ_" + hdbSeq.TableName + "_" + hdbSeq.FieldName
+						hdbSeq.SeqName = qualifiedName(hdbSeq.TableName, "SEQ_"+strings.ToUpper(bareTableName(tn))+"_"+hdbSeq.FieldName)
 						if hdbSeq.Start == 0 {
 							hdbSeq.Start = 1
 						}
-						// warn that user-specified db_type type will be ignored
-						if col.uType != "" {
-							log.Printf("WARNING: %s auto-incrementing primary-key field %s has user-specified db_type: %s  user-type is ignored. \n", common.GetTableName(ent), col.fName, col.uType)
-							col.uType = ""
-						}
 						col.fAutoInc = true
 					}
 
@@ -469,7 +516,7 @@ func (hf *HDBFlavor) buildTablSchema(tn string, ent interface{}) TblComponents {
 					}
 
 				case "default":
-					if fd.UnderGoType == "string" {
+					if fd.UnderGoType == "string" || fd.UnderGoType == common.ValuerType || fd.UnderGoType == common.JSONType {
 						col.fDefault = "DEFAULT '" + p.Value + "'"
 					} else {
 						col.fDefault = "DEFAULT " + p.Value
@@ -506,8 +553,16 @@ func (hf *HDBFlavor) buildTablSchema(tn string, ent interface{}) TblComponents {
 				case "constraint":
 					if p.Value == "unique" {
 						col.fUniqueConstraint = "UNIQUE"
+					} else {
+						constraints = hf.processConstraintTag(constraints, tn, fd.FName, p.Value)
 					}
 
+				case "check":
+					constraints = hf.processCheckTag(constraints, tn, fd.FName, p.Value)
+
+				case "enum":
+					constraints = hf.processEnumTag(constraints, tn, fd.FName, p.Value)
+
 				case "index":
 					switch p.Value {
 					case "non-unique":
@@ -543,6 +598,9 @@ func (hf *HDBFlavor) buildTablSchema(tn string, ent interface{}) TblComponents {
 			}
 		}
 		fldef[idx].FType = col.fType
+		if col.uType != "" {
+			fldef[idx].FType = col.uType
+		}
 
 		// record the sequence(no start-value)
 		if col.fAutoInc {
@@ -550,6 +608,9 @@ func (hf *HDBFlavor) buildTablSchema(tn string, ent interface{}) TblComponents {
 			hdbSeq = hdbSeqTyp{}
 		}
 
+		// retain the column definition for comparison in AlterTables
+		columns = append(columns, col)
+
 		// add the current column to the schema
 		if col.uType != "" {
 			tableSchema = tableSchema + qt + col.fName + qt + " " + col.uType
@@ -574,6 +635,11 @@ func (hf *HDBFlavor) buildTablSchema(tn string, ent interface{}) TblComponents {
 		tableSchema = tableSchema + ", "
 	}
 
+	// add the table-level unique and check constraints
+	for _, cn := range hf.constraintNames(constraints) {
+		tableSchema = tableSchema + hf.constraintClause(cn, constraints[cn]) + ", "
+	}
+
 	if tableSchema != "" && pKeys == "" {
 		tableSchema = strings.TrimSpace(tableSchema)
 		tableSchema = strings.TrimSuffix(tableSchema, ",")
@@ -591,6 +657,8 @@ func (hf *HDBFlavor) buildTablSchema(tn string, ent interface{}) TblComponents {
 		flDef:     fldef,
 		seq:       sequences,
 		ind:       indexes,
+		cons:      constraints,
+		cols:      columns,
 		fkey:      fKeys,
 		pk:        pKeys,
 		err:       err,
@@ -606,66 +674,145 @@ func (hf *HDBFlavor) buildTablSchema(tn string, ent interface{}) TblComponents {
 // by hf.DB.
 func (hf *HDBFlavor) CreateTables(i ...interface{}) error {
 
+	// views are created once the tables are in place
+	i, views := splitViews(i)
+
 	// call createTables specifying that the call has not originated
 	// from within the AlterTables(...) method.
 	_, err := hf.createTables(false, i)
 	if err != nil {
 		return err
 	}
-	return nil
+	return hf.createViews(hf, views...)
+}
+
+// hdbSPField describes an IN parameter of the insert procedure of a table,
+// along with the default value of its column.
+type hdbSPField struct {
+	Name    string
+	Type    string
+	Default string
+}
+
+// insertSPName returns the name of the insert procedure of table tn.  The
+// procedure lives in the schema of the table.
+func (hf *HDBFlavor) insertSPName(tn string) string {
+	return qualifiedName(tn, "INS_"+strings.ToUpper(bareTableName(tn)))
 }
 
-func (hf *HDBFlavor) createInsertSP(seqDef hdbSeqTyp, fldef []common.FieldDef) error {
+// createInsertSP creates or replaces the insert procedure of table tn, if
+// the table has an auto-incrementing key.  The procedure draws the key from
+// the sequence of the table, inserts the row and returns the key via its
+// OUT parameter, so that Create is able to insert a row in a single call.
+func (hf *HDBFlavor) createInsertSP(tn string, tc TblComponents) error {
 
 	type tmplDataTyp struct {
-		Header hdbSeqTyp
-		Fields []common.FieldDef
+		Header   hdbSeqTyp
+		ProcName string
+		KeyType  string
+		Fields   []hdbSPField
 	}
 
 	var tmplData tmplDataTyp
-	tmplData.Header = seqDef
-	tmplData.Fields = fldef
-
-	log.Println("createInsertSP seqDef:", seqDef)
-	for _, v := range fldef {
-		log.Println(v)
+	for _, col := range tc.cols {
+		if col.fAutoInc {
+			tmplData.Header = hdbSeqTyp{
+				TableName: tn,
+				FieldName: col.fName,
+				Start:     col.fStart,
+				SeqName:   hf.sequenceName(tn, ColumnInfo{Name: col.fName}),
+			}
+			tmplData.KeyType = col.fType
+			continue
+		}
+		fld := hdbSPField{Name: col.fName, Type: col.fType, Default: strings.TrimPrefix(col.fDefault, "DEFAULT ")}
+		if col.uType != "" {
+			fld.Type = col.uType
+		}
+		tmplData.Fields = append(tmplData.Fields, fld)
+	}
+	if tmplData.Header.FieldName == "" {
+		return nil
 	}
+	tmplData.ProcName = hf.insertSPName(tn)
 
-	spTemplate := template.New("Entity Insert SP")
-	spTemplate, err := template.ParseFiles("templates/createInsertSP.gotmpl")
+	spTemplate, err := template.New("createInsertSP").Parse(insertSPTemplate)
 	if err != nil {
 		return err
 	}
 
 	var buf bytes.Buffer
-
 	err = spTemplate.Execute(&buf, tmplData)
 	if err != nil {
 		return err
 	}
 
-	procDDL := buf.String()
+	// the procedure is rendered as a single-line statement
+	procDDL := strings.Join(strings.Fields(buf.String()), " ")
 	if hf.log {
 		log.Println("createInsertSP:", procDDL)
 	}
 
 	// attempt to create the procedure on the db
-	_, err = hf.db.Exec(procDDL)
+	_, err = hf.Exec(procDDL)
 	if err != nil {
 		return err
 	}
+	if !hf.planning {
+		hf.insertSPs.Store(tmplData.ProcName, true)
+	}
 	return nil
 }
 
+// hasInsertSP reports whether the insert procedure of table tn exists.
+// The catalog is consulted the first time the procedure is looked up;
+// after that the answer is taken from the handle, which keeps track of
+// the procedures it creates and drops.
+func (hf *HDBFlavor) hasInsertSP(tn string) bool {
+
+	pn := hf.insertSPName(tn)
+	if ok, found := hf.insertSPs.Load(pn); found {
+		return ok.(bool)
+	}
+	ok := hf.existsProcedure(pn)
+	hf.insertSPs.Store(pn, ok)
+	return ok
+}
+
+// forgetInsertSP discards what is known about the insert procedure of
+// table tn, so that its next lookup consults the catalog.
+func (hf *HDBFlavor) forgetInsertSP(tn string) {
+	hf.insertSPs.Delete(hf.insertSPName(tn))
+}
+
+// existsProcedure checks the connected HDB database for the presence of
+// procedure pn.
+func (hf *HDBFlavor) existsProcedure(pn string) bool {
+
+	n := 0
+	sn, bn := hdbSchema(pn)
+	qs := "SELECT COUNT(*) FROM Sys.Procedures WHERE SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND PROCEDURE_NAME = ?;"
+	hf.QsLog(qs, sn, bn)
+	hf.db.QueryRow(qs, sn, bn).Scan(&n)
+	return n > 0
+}
+
 // DropTables drops tables on the db if they exist, based on
 // the provided list of go struct definitions.
 func (hf *HDBFlavor) DropTables(i ...interface{}) error {
 
+	// views are dropped ahead of the tables they select from
+	i, views := splitViews(i)
+	err := hf.dropViews(hf, views...)
+	if err != nil {
+		return err
+	}
+
 	dropSchema := ""
 	for t := range i {
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := hf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in hf.DropTables")
 		}
@@ -677,9 +824,16 @@ func (hf *HDBFlavor) DropTables(i ...interface{}) error {
 			if hf.log {
 				log.Printf("table %s exists - adding to drop schema...\n", tn)
 			}
-			dropSchema = dropSchema + "DROP TABLE " + strings.ToUpper(tn) + ";"
+			dropSchema = dropSchema + hf.dropTableSQL(tn)
 			hf.ProcessSchema(dropSchema)
+			hf.dropInPlan(tn)
 			dropSchema = ""
+
+			// drop the insert procedure of the table
+			if hf.existsProcedure(hf.insertSPName(tn)) {
+				hf.ProcessSchema("DROP PROCEDURE " + hf.insertSPName(tn) + ";")
+			}
+			hf.forgetInsertSP(tn)
 		}
 	}
 	return nil
@@ -690,6 +844,7 @@ func (hf *HDBFlavor) DropTables(i ...interface{}) error {
 func (hf *HDBFlavor) AlterTables(i ...interface{}) error {
 
 	var err error
+	i, views := splitViews(i)
 	fkBuffer := make([]ForeignKeyBuffer, 0)
 	ci := make([]interface{}, 0)
 	ai := make([]interface{}, 0)
@@ -698,11 +853,21 @@ func (hf *HDBFlavor) AlterTables(i ...interface{}) error {
 	for t := range i {
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := hf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in hf.AlterTables")
 		}
 
+		// rename the table of a model tagged with renamed_from; the
+		// renamed table cannot be examined in plan mode.
+		renamed, err := hf.renameTable(hf, tn, i[t])
+		if err != nil {
+			return err
+		}
+		if renamed && hf.planning {
+			continue
+		}
+
 		// if the table does not exist, add the Model{} definition to
 		// the CreateTables buffer (ci).
 		// if the table does exist, add the Model{} definition to  the
@@ -728,7 +893,7 @@ func (hf *HDBFlavor) AlterTables(i ...interface{}) error {
 	for t, ent := range ai {
 
 		// determine the table name
-		tn := common.GetTableName(ai[t])
+		tn := hf.TableName(ai[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in hf.AlterTables")
 		}
@@ -736,15 +901,21 @@ func (hf *HDBFlavor) AlterTables(i ...interface{}) error {
 		// build the altered table schema and get its components
 		tc := hf.buildTablSchema(tn, ai[t])
 
+		// rename the columns of fields tagged with renamed_from
+		renamed, err := hf.renameColumns(hf, tn, tc)
+		if err != nil {
+			return err
+		}
+
 		// go through the latest version of the model and check each
 		// field against its definition in the database.
 		qt := hf.GetDBQuote()
-		alterSchema := "ALTER TABLE " + qt + tn + qt + " ADD ("
+		alterSchema := "ALTER TABLE " + quotedTableName(tn, qt) + " ADD ("
 		var cols []string
 
 		for _, fd := range tc.flDef {
 			// new columns first
-			if !hf.ExistsColumn(tn, fd.FName) && fd.NoDB == false {
+			if !hf.ExistsColumn(tn, fd.FName) && !renamed[fd.FName] && fd.NoDB == false {
 
 				colSchema := qt + fd.FName + qt + " " + fd.FType
 				for _, p := range fd.SqacPairs {
@@ -755,7 +926,7 @@ func (hf *HDBFlavor) AlterTables(i ...interface{}) error {
 
 					case "default":
 						switch fd.UnderGoType {
-						case "string":
+						case "string", common.ValuerType, common.JSONType:
 							colSchema = colSchema + " DEFAULT '" + p.Value + "'"
 
 						case "bool":
@@ -796,13 +967,52 @@ func (hf *HDBFlavor) AlterTables(i ...interface{}) error {
 			hf.ProcessSchema(alterSchema)
 		}
 
+		// bring the existing columns in line with the model
+		err = hf.alterColumns(tn, tc)
+		if err != nil {
+			return err
+		}
+
+		// regenerate the insert procedure to match the altered table
+		err = hf.createInsertSP(tn, tc)
+		if err != nil {
+			return err
+		}
+
 		// add indexes if required
-		for k, v := range tc.ind {
+		for _, k := range indexNames(tc.ind) {
+			v := tc.ind[k]
 			if !hf.ExistsIndex(v.TableName, k) {
 				hf.CreateIndex(k, v)
 			}
 		}
 
+		// add unique and check constraints if required
+		for _, cn := range hf.constraintNames(tc.cons) {
+			if !hf.ExistsConstraint(tn, cn) {
+				err = hf.CreateConstraint(cn, tc.cons[cn])
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// re-create the check constraints whose expression has changed
+		changed, err := hf.changedChecks(hf, tn, tc.cons)
+		if err != nil {
+			return err
+		}
+		for _, cn := range changed {
+			err = hf.DropConstraint(tn, cn)
+			if err != nil {
+				return err
+			}
+			err = hf.CreateConstraint(cn, tc.cons[cn])
+			if err != nil {
+				return err
+			}
+		}
+
 		// add to the list of foreign-keys
 		for _, v := range tc.fkey {
 			fkb := ForeignKeyBuffer{
@@ -831,7 +1041,66 @@ func (hf *HDBFlavor) AlterTables(i ...interface{}) error {
 			}
 		}
 	}
-	return nil
+	return hf.createViews(hf, views...)
+}
+
+// SetDB sets the sqlx.DB connection of the handle.  What the handle
+// knows about the insert procedures of the tables is discarded.
+func (hf *HDBFlavor) SetDB(db *sqlx.DB) {
+	hf.BaseFlavor.SetDB(db)
+	hf.insertSPs = new(sync.Map)
+}
+
+// ForTenant returns a handle sharing the connection to the HDB database, whose
+// table names are routed to the tables of tenant via the tenant resolver.
+func (hf *HDBFlavor) ForTenant(tenant string) PublicDB {
+	t := *hf
+	t.tenant = tenant
+	return &t
+}
+
+// CreateSchema creates schema sn on the connected HDB database if it
+// does not exist.
+func (hf *HDBFlavor) CreateSchema(sn string) error {
+	return hf.createSchema(hf, sn)
+}
+
+// DropSchema drops schema sn on the connected HDB database if it exists.
+// Schemas still holding objects are not dropped.
+func (hf *HDBFlavor) DropSchema(sn string) error {
+	return hf.dropSchema(hf, sn)
+}
+
+// ExistsSchema checks the connected HDB database for the presence of
+// schema sn.
+func (hf *HDBFlavor) ExistsSchema(sn string) bool {
+
+	n := 0
+	qs := "SELECT COUNT(*) FROM Sys.Schemas WHERE SCHEMA_NAME = ?;"
+	hf.QsLog(qs, strings.ToUpper(sn))
+	err := hf.db.QueryRow(qs, strings.ToUpper(sn)).Scan(&n)
+	if err != nil {
+		return false
+	}
+	return n > 0
+}
+
+// createSchemaSQL returns the statement used to create schema sn.
+func (hf *HDBFlavor) createSchemaSQL(sn string) (string, error) {
+	return "CREATE SCHEMA " + sn + ";", nil
+}
+
+// dropSchemaSQL returns the statement used to drop schema sn.
+func (hf *HDBFlavor) dropSchemaSQL(sn string) (string, error) {
+	return "DROP SCHEMA " + sn + " RESTRICT;", nil
+}
+
+// hdbSchema splits table name tn into its upper-cased schema and table
+// name.  The schema is empty if tn is not qualified, in which case the
+// queries fall back to the current schema of the connection.
+func hdbSchema(tn string) (string, string) {
+	sn, n := splitTableName(tn)
+	return strings.ToUpper(sn), strings.ToUpper(n)
 }
 
 // ExistsTable checks the currently connected database and
@@ -839,23 +1108,57 @@ func (hf *HDBFlavor) AlterTables(i ...interface{}) error {
 func (hf *HDBFlavor) ExistsTable(tn string) bool {
 
 	n := 0
-	etQuery := "SELECT COUNT(*) FROM Sys.Tables WHERE TABLE_NAME = '" + strings.ToUpper(tn) + "';"
-	hf.QsLog(etQuery)
-	hf.db.QueryRow(etQuery).Scan(&n)
+	sn, bn := hdbSchema(tn)
+	etQuery := "SELECT COUNT(*) FROM Sys.Tables WHERE SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND TABLE_NAME = ?;"
+	hf.QsLog(etQuery, sn, bn)
+	hf.db.QueryRow(etQuery, sn, bn).Scan(&n)
 	if n > 0 {
 		return true
 	}
 	return false
 }
 
+// ExistsView checks the connected HDB database for the presence of view
+// vn.
+func (hf *HDBFlavor) ExistsView(vn string) bool {
+
+	n := 0
+	sn, bn := hdbSchema(vn)
+	qs := "SELECT COUNT(*) FROM Sys.Views WHERE SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND VIEW_NAME = ?;"
+	hf.QsLog(qs, sn, bn)
+	hf.db.QueryRow(qs, sn, bn).Scan(&n)
+	return n > 0
+}
+
+// DropView drops view vn on the connected HDB database if it exists.
+func (hf *HDBFlavor) DropView(vn string) error {
+	return hf.dropView(hf, vn)
+}
+
 // ExistsIndex checks the connected database for the presence
 // of the specified index.
 func (hf *HDBFlavor) ExistsIndex(tn string, in string) bool {
 
 	n := 0
-	qs := "SELECT COUNT(*) FROM sys.indexes WHERE index_name=? AND table_name = ?;"
-	hf.QsLog(qs, strings.ToUpper(in), strings.ToUpper(tn))
-	hf.db.QueryRow(qs, strings.ToUpper(in), strings.ToUpper(tn)).Scan(&n)
+	sn, bn := hdbSchema(tn)
+	qs := "SELECT COUNT(*) FROM sys.indexes WHERE index_name=? AND schema_name = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND table_name = ?;"
+	hf.QsLog(qs, strings.ToUpper(in), sn, bn)
+	hf.db.QueryRow(qs, strings.ToUpper(in), sn, bn).Scan(&n)
+	if n > 0 {
+		return true
+	}
+	return false
+}
+
+// ExistsConstraint checks the connected database for the presence of
+// the named unique or check constraint on table tn.
+func (hf *HDBFlavor) ExistsConstraint(tn string, cn string) bool {
+
+	n := 0
+	sn, bn := hdbSchema(tn)
+	qs := "SELECT COUNT(*) FROM Sys.Constraints WHERE schema_name = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND table_name = ? AND constraint_name = ?;"
+	hf.QsLog(qs, sn, bn, strings.ToUpper(cn))
+	hf.db.QueryRow(qs, sn, bn, strings.ToUpper(cn)).Scan(&n)
 	if n > 0 {
 		return true
 	}
@@ -866,13 +1169,18 @@ func (hf *HDBFlavor) ExistsIndex(tn string, in string) bool {
 func (hf *HDBFlavor) DropIndex(tn string, in string) error {
 
 	if hf.ExistsIndex(tn, in) {
-		indexSchema := "DROP INDEX " + strings.ToUpper(in) + ";"
+		indexSchema := hf.dropIndexSQL(tn, in)
 		hf.ProcessSchema(indexSchema)
 		return nil
 	}
 	return nil
 }
 
+// dropIndexSQL returns the statement used to drop index in.
+func (hf *HDBFlavor) dropIndexSQL(tn string, in string) string {
+	return "DROP INDEX " + qualifiedName(tn, strings.ToUpper(in)) + ";"
+}
+
 // ExistsColumn checks the currently connected database and
 // returns true if the named table-column is found to exist.
 // this checks the column name only, not the column data-type
@@ -880,11 +1188,11 @@ func (hf *HDBFlavor) DropIndex(tn string, in string) error {
 func (hf *HDBFlavor) ExistsColumn(tn string, cn string) bool {
 
 	n := 0
-	tn = strings.ToUpper(tn)
 	if hf.ExistsTable(tn) {
-		qs := "SELECT COUNT(*) FROM Sys.Table_Columns WHERE table_name = ? AND column_name = ?;"
-		hf.QsLog(qs, tn, strings.ToUpper(cn))
-		hf.db.QueryRow(qs, tn, strings.ToUpper(cn)).Scan(&n)
+		sn, bn := hdbSchema(tn)
+		qs := "SELECT COUNT(*) FROM Sys.Table_Columns WHERE schema_name = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND table_name = ? AND column_name = ?;"
+		hf.QsLog(qs, sn, bn, strings.ToUpper(cn))
+		hf.db.QueryRow(qs, sn, bn, strings.ToUpper(cn)).Scan(&n)
 		if n > 0 {
 			return true
 		}
@@ -892,6 +1200,280 @@ func (hf *HDBFlavor) ExistsColumn(tn string, cn string) bool {
 	return false
 }
 
+// readColumns reads the column definitions of table tn from the
+// system views of the connected HDB database.
+func (hf *HDBFlavor) readColumns(tn string) ([]ColumnInfo, error) {
+
+	qs := "SELECT c.COLUMN_NAME, c.DATA_TYPE_NAME, c.LENGTH, COALESCE(c.SCALE, 0), c.IS_NULLABLE, COALESCE(c.DEFAULT_VALUE, ''), " +
+		"(SELECT COUNT(*) FROM Sys.Constraints k WHERE k.SCHEMA_NAME = c.SCHEMA_NAME AND k.TABLE_NAME = c.TABLE_NAME " +
+		"AND k.COLUMN_NAME = c.COLUMN_NAME AND k.IS_PRIMARY_KEY = 'TRUE'), " +
+		"(SELECT COUNT(*) FROM Sys.Sequences s WHERE s.SCHEMA_NAME = c.SCHEMA_NAME AND s.SEQUENCE_NAME = 'SEQ_' || c.TABLE_NAME || '_' || c.COLUMN_NAME) " +
+		"FROM Sys.Table_Columns c WHERE c.SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND c.TABLE_NAME = ? ORDER BY c.POSITION;"
+	sn, bn := hdbSchema(tn)
+	hf.QsLog(qs, sn, bn)
+
+	rows, err := hf.db.Query(qs, sn, bn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols := make([]ColumnInfo, 0)
+	for rows.Next() {
+		var ci ColumnInfo
+		var length, scale, pk, seq int
+		var nullable string
+		err = rows.Scan(&ci.Name, &ci.Type, &length, &scale, &nullable, &ci.Default, &pk, &seq)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(ci.Type) {
+		case "varchar", "nvarchar", "char", "nchar", "varbinary", "alphanum", "shorttext":
+			ci.Type = ci.Type + "(" + strconv.Itoa(length) + ")"
+		case "decimal":
+			ci.Type = ci.Type + "(" + strconv.Itoa(length) + "," + strconv.Itoa(scale) + ")"
+		}
+		ci.Nullable = nullable == "TRUE"
+		ci.PrimaryKey = pk > 0
+		ci.Identity = ci.PrimaryKey && seq > 0
+		cols = append(cols, ci)
+	}
+	return hf.planColumns(tn, cols), rows.Err()
+}
+
+// normalizeColumn maps the HDB type aliases and default expression
+// formats onto a common representation for column comparison.
+func (hf *HDBFlavor) normalizeColumn(ci ColumnInfo) ColumnInfo {
+
+	t := strings.ToLower(strings.Replace(strings.TrimSpace(ci.Type), " ", "", -1))
+	if t == "int" {
+		t = "integer"
+	}
+	ci.Type = t
+
+	d := trimDefault(ci.Default)
+	switch strings.ToLower(d) {
+	case "true":
+		d = "1"
+	case "false":
+		d = "0"
+	}
+	ci.Default = d
+	return ci
+}
+
+// readTableNames reads the names of the tables in the schema set via
+// SetSchema, or the current schema of the connected HDB database.  HDB
+// reports the names in upper-case, so they are lower-cased to match the
+// sqac table names.
+func (hf *HDBFlavor) readTableNames() ([]string, error) {
+
+	qs := "SELECT TABLE_NAME FROM Sys.Tables WHERE SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) ORDER BY TABLE_NAME;"
+	hf.QsLog(qs, strings.ToUpper(hf.schema))
+
+	tns := make([]string, 0)
+	err := hf.db.Select(&tns, qs, strings.ToUpper(hf.schema))
+	if err != nil {
+		return nil, err
+	}
+	for i := range tns {
+		tns[i] = strings.ToLower(tns[i])
+	}
+	return tns, nil
+}
+
+// readIndexes reads the indexes of table tn from the system views of the
+// connected HDB database.  The primary-key index is not included.
+func (hf *HDBFlavor) readIndexes(tn string) (map[string]IndexInfo, error) {
+
+	qs := "SELECT INDEX_NAME, COALESCE(CONSTRAINT, ''), COLUMN_NAME FROM Sys.Index_Columns " +
+		"WHERE SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND TABLE_NAME = ? AND COALESCE(CONSTRAINT, '') <> 'PRIMARY KEY' " +
+		"ORDER BY INDEX_NAME, POSITION;"
+	sn, bn := hdbSchema(tn)
+	hf.QsLog(qs, sn, bn)
+
+	rows, err := hf.db.Query(qs, sn, bn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ind := make(map[string]IndexInfo)
+	for rows.Next() {
+		var in, constraint, cn string
+		err = rows.Scan(&in, &constraint, &cn)
+		if err != nil {
+			return nil, err
+		}
+		addIndexField(ind, tn, strings.ToLower(in), strings.HasSuffix(constraint, "UNIQUE"), strings.ToLower(cn))
+	}
+	return ind, rows.Err()
+}
+
+// readForeignKeys reads the foreign-keys of table tn from the system
+// views of the connected HDB database.
+func (hf *HDBFlavor) readForeignKeys(tn string) ([]FKeyInfo, error) {
+
+	qs := "SELECT CONSTRAINT_NAME, COLUMN_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME FROM Sys.Referential_Constraints " +
+		"WHERE SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND TABLE_NAME = ? ORDER BY CONSTRAINT_NAME, POSITION;"
+	sn, bn := hdbSchema(tn)
+	hf.QsLog(qs, sn, bn)
+
+	rows, err := hf.db.Query(qs, sn, bn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fks := make([]FKeyInfo, 0)
+	for rows.Next() {
+		fk := FKeyInfo{FromTable: tn}
+		err = rows.Scan(&fk.FKeyName, &fk.FromField, &fk.RefTable, &fk.RefField)
+		if err != nil {
+			return nil, err
+		}
+		fk.FKeyName = strings.ToLower(fk.FKeyName)
+		fk.FromField = strings.ToLower(fk.FromField)
+		fk.RefTable = strings.ToLower(fk.RefTable)
+		fk.RefField = strings.ToLower(fk.RefField)
+		fks = append(fks, fk)
+	}
+	return fks, rows.Err()
+}
+
+// readChecks reads the names and definitions of the check constraints
+// of table tn from the system views of the connected HDB database.
+func (hf *HDBFlavor) readChecks(tn string) (map[string]string, error) {
+
+	qs := "SELECT CONSTRAINT_NAME, CHECK_CONDITION FROM SYS.CONSTRAINTS WHERE SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) " +
+		"AND TABLE_NAME = ? AND CHECK_CONDITION IS NOT NULL;"
+	sn, bn := hdbSchema(tn)
+	hf.QsLog(qs, sn, bn)
+
+	rows, err := hf.db.Query(qs, sn, bn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := make(map[string]string)
+	for rows.Next() {
+		var cn, def string
+		err = rows.Scan(&cn, &def)
+		if err != nil {
+			return nil, err
+		}
+		checks[cn] = def
+	}
+	return checks, rows.Err()
+}
+
+// sequenceName returns the name of the sequence that sqac creates for
+// identity column ci of table tn.  The sequence lives in the schema of
+// the table.
+func (hf *HDBFlavor) sequenceName(tn string, ci ColumnInfo) string {
+	return qualifiedName(tn, "SEQ_"+strings.ToUpper(bareTableName(tn))+"_"+strings.ToUpper(ci.Name))
+}
+
+// renameColumnSQL returns the statement renaming column from of table tn
+// to column to.
+func (hf *HDBFlavor) renameColumnSQL(tn, from, to string) string {
+	return "RENAME COLUMN " + tn + "." + from + " TO " + to + ";"
+}
+
+// renameTableSQL returns the statements renaming table from to table to.
+// The sequences feeding the identity columns of the table are named after
+// the table, so they are replaced by sequences named after table to that
+// continue from the highest key value held in the table.  The insert
+// procedure of the table is dropped; AlterTables creates the procedure of
+// table to.
+func (hf *HDBFlavor) renameTableSQL(from, to string) ([]string, error) {
+
+	stmts := []string{"RENAME TABLE " + from + " TO " + bareTableName(to) + ";"}
+
+	cols, err := hf.readColumns(from)
+	if err != nil {
+		return nil, err
+	}
+	for _, ci := range cols {
+		if !ci.Identity {
+			continue
+		}
+		var start int
+		err = hf.db.QueryRow("SELECT IFNULL(MAX(" + ci.Name + "), 0) + 1 FROM " + from + ";").Scan(&start)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts,
+			"CREATE SEQUENCE "+hf.sequenceName(to, ci)+" START WITH "+strconv.Itoa(start)+" INCREMENT BY 1;",
+			"DROP SEQUENCE "+hf.sequenceName(from, ci)+";")
+	}
+	if hf.existsProcedure(hf.insertSPName(from)) {
+		stmts = append(stmts, "DROP PROCEDURE "+hf.insertSPName(from)+";")
+	}
+	hf.forgetInsertSP(from)
+	return stmts, nil
+}
+
+// alterColumns brings the existing columns of table tn in line with the
+// model captured in tc.  Type, nullability and default changes are
+// applied by way of ALTER TABLE ... ALTER (...), columns that are no
+// longer part of the model are dealt with according to the destructive-
+// change policy.
+func (hf *HDBFlavor) alterColumns(tn string, tc TblComponents) error {
+
+	dbCols, err := hf.readColumns(tn)
+	if err != nil {
+		return err
+	}
+
+	deltas := hf.diffColumns(hf.modelColumns(tc), dbCols, hf.normalizeColumn)
+	deltas, err = hf.applyDestructivePolicy(tn, deltas)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range hf.alterColumnSQL(tn, deltas) {
+		_, err = hf.Exec(s)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// alterColumnSQL returns the statements that apply the column deltas
+// to table tn.
+func (hf *HDBFlavor) alterColumnSQL(tn string, deltas []colDelta) []string {
+
+	qt := hf.GetDBQuote()
+	alterSchema := "ALTER TABLE " + quotedTableName(tn, qt)
+	var stmts []string
+	for _, d := range deltas {
+		switch d.op {
+		case "DROP":
+			stmts = append(stmts, alterSchema+" DROP ("+qt+d.name+qt+");")
+
+		case "ALTER":
+			// ALTER (...) restates the complete column definition
+			colSchema := " ALTER (" + qt + d.name + qt + " " + d.to.Type
+			if d.to.Default != "" {
+				colSchema = colSchema + " DEFAULT " + d.to.Default
+			}
+			if d.to.Nullable {
+				colSchema = colSchema + " NULL"
+			} else {
+				if d.nullChg && d.to.Default != "" {
+					stmts = append(stmts, "UPDATE "+quotedTableName(tn, qt)+" SET "+qt+d.name+qt+" = "+d.to.Default+" WHERE "+qt+d.name+qt+" IS NULL;")
+				}
+				colSchema = colSchema + " NOT NULL"
+			}
+			stmts = append(stmts, alterSchema+colSchema+");")
+		}
+	}
+	return stmts
+}
+
 // DestructiveResetTables drops tables on the HDB db if they exist,
 // as well as any related objects such as sequences.  this is
 // useful if you wish to regenerated your table and the
@@ -909,6 +1491,97 @@ func (hf *HDBFlavor) DestructiveResetTables(i ...interface{}) error {
 	return nil
 }
 
+// PlanCreateTables returns the ordered list of statements that CreateTables
+// would execute on the connected HDB db, without making any changes.
+func (hf *HDBFlavor) PlanCreateTables(i ...interface{}) ([]string, error) {
+	return hf.runPlan(func() error { return hf.CreateTables(i...) })
+}
+
+// PlanAlterTables returns the ordered list of statements that AlterTables
+// would execute on the connected HDB db, without making any changes.
+func (hf *HDBFlavor) PlanAlterTables(i ...interface{}) ([]string, error) {
+	return hf.runPlan(func() error { return hf.AlterTables(i...) })
+}
+
+// PlanDropTables returns the ordered list of statements that DropTables
+// would execute on the connected HDB db, without making any changes.
+func (hf *HDBFlavor) PlanDropTables(i ...interface{}) ([]string, error) {
+	return hf.runPlan(func() error { return hf.DropTables(i...) })
+}
+
+// PlanDestructiveResetTables returns the ordered list of statements that
+// DestructiveResetTables would execute on the connected HDB db, without
+// making any changes.
+func (hf *HDBFlavor) PlanDestructiveResetTables(i ...interface{}) ([]string, error) {
+	return hf.runPlan(func() error { return hf.DestructiveResetTables(i...) })
+}
+
+// SnapshotTables returns a snapshot of the HDB tables described by
+// the models.
+func (hf *HDBFlavor) SnapshotTables(i ...interface{}) (Snapshot, error) {
+	return hf.snapshotTables(hf, i...)
+}
+
+// PlanMigration returns the statements required to migrate the HDB
+// tables from their state in the connected db, or in snapshot from, to
+// the models, along with the statements required to revert the migration.
+func (hf *HDBFlavor) PlanMigration(from *Snapshot, i ...interface{}) ([]string, []string, error) {
+	return hf.planMigration(hf, from, i...)
+}
+
+// GenerateModels reads the named HDB tables, or all tables if no names
+// are provided, and returns the source of a go file in package pkg holding
+// a sqac-tagged model for each of them.
+func (hf *HDBFlavor) GenerateModels(pkg string, tn ...string) ([]byte, error) {
+	return hf.generateModels(hf, pkg, tn...)
+}
+
+// DescribeTable reads the columns, primary-key, indexes, foreign-keys and
+// sequences of HDB table tn.
+func (hf *HDBFlavor) DescribeTable(tn string) (TableInfo, error) {
+	return hf.describeTable(hf, tn)
+}
+
+// tableComponents returns the components of the HDB table described
+// by model ent.
+func (hf *HDBFlavor) tableComponents(tn string, ent interface{}) TblComponents {
+	return hf.buildTablSchema(tn, ent)
+}
+
+// dropTableSQL returns the statement used to drop table tn.
+func (hf *HDBFlavor) dropTableSQL(tn string) string {
+	return "DROP TABLE " + strings.ToUpper(tn) + ";"
+}
+
+// dropTableObjectsSQL returns the statements used to drop the sequences
+// and the insert procedure that are created along with table tn, as
+// described by model ent.  Dropping the table leaves them in place.
+func (hf *HDBFlavor) dropTableObjectsSQL(tn string, ent interface{}) []string {
+
+	stmts := make([]string, 0)
+	for _, col := range hf.buildTablSchema(tn, ent).cols {
+		if col.fAutoInc {
+			stmts = append(stmts, "DROP PROCEDURE "+hf.insertSPName(tn)+";")
+			stmts = append(stmts, "DROP SEQUENCE "+strings.ToUpper(hf.sequenceName(tn, ColumnInfo{Name: col.fName}))+";")
+		}
+	}
+	return stmts
+}
+
+// addColumnSQL returns the statement used to add column ci to table tn.
+func (hf *HDBFlavor) addColumnSQL(tn string, ci ColumnInfo) string {
+
+	qt := hf.GetDBQuote()
+	colSchema := "ALTER TABLE " + quotedTableName(tn, qt) + " ADD (" + qt + ci.Name + qt + " " + ci.Type
+	if ci.Default != "" {
+		colSchema = colSchema + " DEFAULT " + ci.Default
+	}
+	if !ci.Nullable {
+		colSchema = colSchema + " NOT NULL"
+	}
+	return colSchema + ");"
+}
+
 // getSequenceNames splits the incoming name field on the '+' sign
 // and then assigns the resulting values to tn and fn respectively.
 func (hf *HDBFlavor) getSequenceName(name string) (seqName string, err error) {
@@ -957,10 +1630,11 @@ func (hf *HDBFlavor) ExistsSequence(sn string) bool {
 
 	// search for sequence by name
 	seqCount := 0
-	seqNameQuery := "SELECT COUNT(*) FROM Sys.Sequences WHERE SEQUENCE_NAME = '" + strings.ToUpper(sn) + "';"
-	hf.QsLog(seqNameQuery)
+	schema, name := hdbSchema(sn)
+	seqNameQuery := "SELECT COUNT(*) FROM Sys.Sequences WHERE SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND SEQUENCE_NAME = ?;"
+	hf.QsLog(seqNameQuery, schema, name)
 
-	err := hf.db.QueryRow(seqNameQuery).Scan(&seqCount)
+	err := hf.db.QueryRow(seqNameQuery, schema, name).Scan(&seqCount)
 	if err != nil {
 		panic(err)
 	}
@@ -976,7 +1650,7 @@ func (hf *HDBFlavor) ExistsSequence(sn string) bool {
 func (hf *HDBFlavor) CreateSequence(sn string, start int) {
 
 	// check for and drop existing sequence if exists
-	if hf.ExistsSequence(strings.ToUpper(sn)) {
+	if !hf.offline && hf.ExistsSequence(strings.ToUpper(sn)) {
 		err := hf.DropSequence(strings.ToUpper(sn))
 		if err != nil {
 			panic(err)
@@ -985,10 +1659,9 @@ func (hf *HDBFlavor) CreateSequence(sn string, start int) {
 
 	// build the sequence creation DDL
 	crtSequence := "CREATE SEQUENCE " + strings.ToUpper(sn) + " START WITH " + strconv.Itoa(start) + " INCREMENT BY 1;"
-	hf.QsLog(crtSequence)
 
 	// attempt to create the sequence on the db
-	_, err := hf.db.Exec(crtSequence)
+	_, err := hf.Exec(crtSequence)
 	if err != nil {
 		panic(err)
 	}
@@ -999,10 +1672,9 @@ func (hf *HDBFlavor) DropSequence(sn string) error {
 
 	// build the sequence creation DDL
 	dropSequence := "DROP SEQUENCE " + strings.ToUpper(sn) + ";"
-	hf.QsLog(dropSequence)
 
 	// attempt to drop the sequence from the db
-	_, err := hf.db.Exec(dropSequence)
+	_, err := hf.Exec(dropSequence)
 	if err != nil {
 		return err
 	}
@@ -1031,11 +1703,11 @@ func (hf *HDBFlavor) GetNextSequenceValue(name string) (int, error) {
 func (hf *HDBFlavor) ExistsForeignKeyByName(i interface{}, fkn string) (bool, error) {
 
 	var count uint64
-	tn := strings.ToUpper(common.GetTableName(i))
-	fkQuery := "SELECT COUNT(*) FROM Sys.Referential_Constraints WHERE TABLE_NAME='" + tn + "' AND CONSTRAINT_NAME='" + strings.ToUpper(fkn) + "';"
-	hf.QsLog(fkQuery)
+	sn, tn := hdbSchema(hf.TableName(i))
+	fkQuery := "SELECT COUNT(*) FROM Sys.Referential_Constraints WHERE SCHEMA_NAME = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA) AND TABLE_NAME = ? AND CONSTRAINT_NAME = ?;"
+	hf.QsLog(fkQuery, sn, tn, strings.ToUpper(fkn))
 
-	err := hf.Get(&count, fkQuery)
+	err := hf.Get(&count, fkQuery, sn, tn, strings.ToUpper(fkn))
 	if err != nil {
 		return false, nil
 	}
@@ -1057,6 +1729,36 @@ func (hf *HDBFlavor) ExistsForeignKeyByFields(i interface{}, ft, rt, ff, rf stri
 	return hf.ExistsForeignKeyByName(i, strings.ToUpper(fkn))
 }
 
+// CallProcedure calls HDB procedure name, passing the fields of in as its
+// IN parameters in field order.  If out points to a struct, its fields
+// are passed as OUT parameters following the IN parameters, and receive
+// the values returned by the procedure.  If out points to a slice of
+// structs, the (first) result set of the procedure is read into it.
+func (hf *HDBFlavor) CallProcedure(name string, in interface{}, out interface{}) error {
+
+	ps, err := procParams(in)
+	if err != nil {
+		return err
+	}
+	outs, err := procOutParams(out)
+	if err != nil {
+		return err
+	}
+
+	args := procArgs(ps)
+	for _, p := range outs {
+		args = append(args, p.outArg())
+	}
+	qs := "CALL " + name + "(" + placeholders(len(args)) + ");"
+	hf.QsLog(qs, args...)
+
+	if isResultSet(out) {
+		return hf.db.Select(out, qs, args...)
+	}
+	_, err = hf.db.Exec(qs, args...)
+	return err
+}
+
 //================================================================
 // CRUD ops
 //================================================================
@@ -1068,69 +1770,85 @@ func (hf *HDBFlavor) Create(ent interface{}) error {
 	info.ent = ent
 	info.log = false
 	info.mode = "C"
-	incKey := 0
+	var incKey int64
 
 	err := hf.BuildComponents(&info)
 	if err != nil {
 		return err
 	}
 
-	// build the hdb insert query
-	insFlds := "("
-	insVals := "("
-
 	if hf.IsLog() {
 		log.Println("info.incKeyName:", info.incKeyName)
 	}
 
-	for k, v := range info.fldMap {
-
-		// pull an id - this is ugly, but hdb does not have a reliable
-		// mechanism to report a new row-id.  Dynamic SQL in a SP may
-		// be better, but would open the door for bad behaviour.
-		if strings.Compare(k, info.incKeyName) == 0 {
-			keyQuery := "SELECT SEQ_" + strings.ToUpper(info.tn) + "_" + strings.ToUpper(info.incKeyName) + ".NEXTVAL FROM DUMMY;"
-			hf.QsLog(keyQuery)
-			err = hf.db.QueryRowx(keyQuery).Scan(&incKey)
-			if err != nil {
-				return err
+	// build the hdb insert query.  tables with an auto-incrementing key
+	// are inserted into via their insert procedure, which passes back the
+	// key drawn from the sequence of the table in the same call.  fields
+	// left to their column default are omitted from the call.  tables
+	// created before the insert procedures were introduced do not have
+	// one until they are altered; their key is drawn from the sequence
+	// ahead of a plain insert.
+	insQuery := ""
+	useSP := info.incKeyName != "" && hf.hasInsertSP(info.tn)
+	if info.incKeyName != "" && !useSP {
+		keyQuery := "SELECT " + hf.sequenceName(info.tn, ColumnInfo{Name: info.incKeyName}) + ".NEXTVAL FROM DUMMY;"
+		hf.QsLog(keyQuery)
+		err = hf.db.QueryRowx(keyQuery).Scan(&incKey)
+		if err != nil {
+			return err
+		}
+	}
+	if useSP {
+		params := ""
+		for _, k := range info.fields() {
+			v := info.fldMap[k]
+			if k == info.incKeyName || v == "DEFAULT" {
+				continue
 			}
-			insFlds = insFlds + k + ", "
-			insVals = insVals + strconv.Itoa(incKey) + ", "
-			continue
+			params = fmt.Sprintf("%s%s => %s, ", params, k, v)
 		}
-
-		if v == "DEFAULT" {
-			continue
+		insQuery = "CALL " + hf.insertSPName(info.tn) + "(" + params + info.incKeyName + " => ?);"
+	} else {
+		insFlds := "("
+		insVals := "("
+		for _, k := range info.fields() {
+			v := info.fldMap[k]
+			if k == info.incKeyName {
+				v = strconv.FormatInt(incKey, 10)
+			} else if v == "DEFAULT" {
+				continue
+			}
+			insFlds = insFlds + k + ", "
+			insVals = fmt.Sprintf("%s%s, ", insVals, v)
 		}
-		insFlds = insFlds + k + ", "
-		insVals = fmt.Sprintf("%s%s, ", insVals, v)
+		insFlds = strings.TrimSuffix(insFlds, ", ") + ")"
+		insVals = strings.TrimSuffix(insVals, ", ") + ")"
+		insQuery = "INSERT INTO " + info.tn + " " + insFlds + " VALUES " + insVals + ";"
 	}
-
-	insFlds = strings.TrimSuffix(insFlds, ", ") + ")"
-	insVals = strings.TrimSuffix(insVals, ", ") + ")"
-
-	// build the hdb insert query
-	insQuery := "INSERT INTO " + info.tn + " " + insFlds + " VALUES " + insVals + ";"
-	hf.QsLog(insQuery)
+	hf.QsLog(insQuery, info.args...)
 
 	// clear the source data - deals with non-persistet columns
 	e := reflect.ValueOf(info.ent).Elem()
 	e.Set(reflect.Zero(e.Type()))
 
 	// attempt the insert and read the result back into info.resultMap
-	_, err = hf.db.Exec(insQuery)
+	if useSP {
+		_, err = hf.db.Exec(insQuery, append(info.args, sql.Out{Dest: &incKey})...)
+	} else {
+		_, err = hf.db.Exec(insQuery, info.args...)
+	}
 	if err != nil {
 		return err
 	}
 
-	selQuery := "SELECT * FROM " + info.tn + " WHERE " + info.incKeyName + " = " + strconv.Itoa(incKey) + ";"
+	selQuery := "SELECT * FROM " + info.tn + " WHERE " + info.keyCondition(incKey) + ";"
 	hf.QsLog(selQuery)
 
-	err = hf.db.QueryRowx(selQuery).StructScan(info.ent) //.MapScan(info.resultMap) // SliceScan
+	err = hf.structScan(hf.db.QueryRowx(selQuery), info.ent) //.MapScan(info.resultMap) // SliceScan
 	if err != nil {
 		return err
 	}
+	hf.readTimes(info.ent)
 	info.entValue = reflect.ValueOf(info.ent)
 	return nil
 }
@@ -1166,20 +1884,21 @@ func (hf *HDBFlavor) Update(ent interface{}) error {
 	keyList = strings.TrimSuffix(keyList, " AND")
 
 	colList := ""
-	for k, v := range info.fldMap {
+	for _, k := range info.fields() {
+		v := info.fldMap[k]
 		colList = fmt.Sprintf("%s %s = %s, ", colList, k, v)
 	}
 	colList = strings.TrimSuffix(colList, ", ")
 
 	updQuery := "UPDATE " + info.tn + " SET " + colList + " WHERE " + keyList + ";"
-	hf.QsLog(updQuery)
+	hf.QsLog(updQuery, info.args...)
 
 	// clear the source data - deals with non-persistet columns
 	e := reflect.ValueOf(info.ent).Elem()
 	e.Set(reflect.Zero(e.Type()))
 
 	// attempt the update and check for errors
-	_, err = hf.db.Exec(updQuery)
+	_, err = hf.db.Exec(updQuery, info.args...)
 	if err != nil {
 		return err
 	}
@@ -1188,10 +1907,11 @@ func (hf *HDBFlavor) Update(ent interface{}) error {
 	selQuery := "SELECT * FROM " + info.tn + " WHERE " + keyList + ";"
 	hf.QsLog(selQuery)
 
-	err = hf.db.QueryRowx(selQuery).StructScan(info.ent) //.MapScan(info.resultMap) // SliceScan
+	err = hf.structScan(hf.db.QueryRowx(selQuery), info.ent) //.MapScan(info.resultMap) // SliceScan
 	if err != nil {
 		return err
 	}
+	hf.readTimes(info.ent)
 	info.entValue = reflect.ValueOf(info.ent)
 	return nil
 }
@@ -0,0 +1,167 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac/common"
+)
+
+// planIndex returns the position of the first statement in the plan
+// that contains all of the fragments, or -1 if there is none.
+func planIndex(plan []string, fragments ...string) int {
+
+	for i, s := range plan {
+		found := true
+		for _, f := range fragments {
+			if !strings.Contains(strings.ToLower(s), strings.ToLower(f)) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return i
+		}
+	}
+	return -1
+}
+
+// TestPlanCreateAndDropTables
+//
+// Plan the creation of tables dock and berth, where berth
+// references dock via foreign-key.  Check that the plan
+// contains the expected statements and that the tables
+// have not been created.  Create the tables and check the
+// plans for DropTables and DestructiveResetTables.
+func TestPlanCreateAndDropTables(t *testing.T) {
+
+	type Dock struct {
+		ID   uint64 `db:"id" sqac:"primary_key:inc;start:1000"`
+		Name string `db:"name" sqac:"nullable:false;index:unique"`
+	}
+
+	type Berth struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		DockID uint64 `db:"dock_id" sqac:"nullable:false;fkey:dock(id)"`
+	}
+
+	err := Handle.DropTables(Berth{}, Dock{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	dtn := common.GetTableName(Dock{})
+	btn := common.GetTableName(Berth{})
+
+	plan, err := Handle.PlanCreateTables(Dock{}, Berth{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	cd := planIndex(plan, "CREATE", "TABLE", dtn)
+	cb := planIndex(plan, "CREATE", "TABLE", btn)
+	if cd == -1 || cb == -1 || cd > cb {
+		t.Errorf("expected CREATE TABLE %s followed by CREATE TABLE %s in plan - got %v", dtn, btn, plan)
+	}
+	if planIndex(plan, "INDEX", "idx_"+dtn+"_name") == -1 {
+		t.Errorf("expected index idx_%s_name in plan - got %v", dtn, plan)
+	}
+	if planIndex(plan, "FOREIGN KEY") == -1 {
+		t.Errorf("expected foreign-key creation in plan - got %v", plan)
+	}
+
+	if Handle.ExistsTable(dtn) || Handle.ExistsTable(btn) {
+		t.Errorf("tables %s and %s should not have been created by PlanCreateTables", dtn, btn)
+	}
+
+	err = Handle.CreateTables(Dock{}, Berth{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	// nothing left to create
+	plan, err = Handle.PlanCreateTables(Dock{}, Berth{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected an empty plan for existing tables - got %v", plan)
+	}
+
+	plan, err = Handle.PlanDropTables(Berth{}, Dock{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 2 || planIndex(plan, "DROP", btn) != 0 || planIndex(plan, "DROP", dtn) != 1 {
+		t.Errorf("expected DROP TABLE %s followed by DROP TABLE %s in plan - got %v", btn, dtn, plan)
+	}
+
+	plan, err = Handle.PlanDestructiveResetTables(Berth{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	db := planIndex(plan, "DROP", btn)
+	cb = planIndex(plan, "CREATE", "TABLE", btn)
+	if db == -1 || cb == -1 || db > cb {
+		t.Errorf("expected DROP TABLE %s followed by CREATE TABLE %s in plan - got %v", btn, btn, plan)
+	}
+
+	if !Handle.ExistsTable(dtn) || !Handle.ExistsTable(btn) {
+		t.Errorf("tables %s and %s should not have been dropped by a plan", dtn, btn)
+	}
+
+	err = Handle.DropTables(Berth{}, Dock{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+}
+
+// TestPlanAlterTables
+//
+// Create table quay, then plan the addition of a column
+// and check that the column has not been added.
+func TestPlanAlterTables(t *testing.T) {
+
+	tn := ""
+
+	{
+		type Quay struct {
+			ID   uint64 `db:"id" sqac:"primary_key:inc"`
+			Name string `db:"name" sqac:"nullable:false;default:north"`
+		}
+
+		err := Handle.DropTables(Quay{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+
+		err = Handle.CreateTables(Quay{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		tn = common.GetTableName(Quay{})
+	}
+
+	type Quay struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		Name   string `db:"name" sqac:"nullable:false;default:north"`
+		Length int    `db:"length" sqac:"nullable:true"`
+	}
+
+	plan, err := Handle.PlanAlterTables(Quay{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if planIndex(plan, "ADD", "length") == -1 {
+		t.Errorf("expected the addition of column length in plan - got %v", plan)
+	}
+
+	if Handle.ExistsColumn(tn, "length") {
+		t.Errorf("column length should not have been added to table %s by PlanAlterTables", tn)
+	}
+
+	err = Handle.DropTables(Quay{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+}
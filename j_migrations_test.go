@@ -0,0 +1,226 @@
+package sqac_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/1414C/sqac"
+	"github.com/1414C/sqac/common"
+	"github.com/1414C/sqac/migrations"
+)
+
+// TestMigrations
+//
+// Run a go-func migration creating table wharf, followed
+// by a SQL-file migration creating and populating table
+// mig_note.  Check the migration status, revert the latest
+// migration and check that a held lock blocks a runner
+// until it has been removed.
+func TestMigrations(t *testing.T) {
+
+	type Wharf struct {
+		ID   uint64 `db:"id" sqac:"primary_key:inc"`
+		Name string `db:"name" sqac:"nullable:false"`
+	}
+	wtn := common.GetTableName(Wharf{})
+
+	// start from a clean slate
+	err := Handle.DropTables(Wharf{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	for _, tn := range []string{"mig_note", "sqac_migrations", "sqac_migrations_lock"} {
+		if Handle.ExistsTable(tn) {
+			_, err = Handle.Exec("DROP TABLE " + tn)
+			if err != nil {
+				t.Errorf("%s", err.Error())
+			}
+		}
+	}
+
+	fsys := fstest.MapFS{
+		"sql/20240102000000_notes.up.sql": &fstest.MapFile{Data: []byte(
+			"-- notes; created via sql\n" +
+				"CREATE TABLE mig_note (id integer not null primary key, txt varchar(40));\n" +
+				"INSERT INTO mig_note (id, txt) VALUES (1, 'a;b');\n")},
+		"sql/20240102000000_notes.down.sql": &fstest.MapFile{Data: []byte("DROP TABLE mig_note;")},
+		"sql/readme.txt":                    &fstest.MapFile{Data: []byte("not a migration")},
+	}
+
+	ms, err := migrations.LoadFS(fsys, "sql")
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(ms) != 1 || ms[0].Version != 20240102000000 || ms[0].Name != "notes" || ms[0].Down == nil {
+		t.Fatalf("unexpected migrations loaded from SQL files: %v", ms)
+	}
+
+	ms = append(ms, migrations.Migration{
+		Version: 20240101000000,
+		Name:    "wharf",
+		Up:      func(db sqac.PublicDB) error { return db.CreateTables(Wharf{}) },
+		Down:    func(db sqac.PublicDB) error { return db.DropTables(Wharf{}) },
+	})
+
+	_, err = migrations.New(Handle, append(ms, ms[0])...)
+	if err == nil {
+		t.Errorf("expected a duplicate version error - got none")
+	}
+
+	m, err := migrations.New(Handle, ms...)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	n, err := m.Up()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if n != 2 {
+		t.Errorf("expected 2 migrations to be applied - got %d", n)
+	}
+	if !Handle.ExistsTable(wtn) || !Handle.ExistsTable("mig_note") {
+		t.Errorf("expected tables %s and mig_note to have been created", wtn)
+	}
+
+	txt := ""
+	err = Handle.Get(&txt, "SELECT txt FROM mig_note WHERE id = 1")
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if txt != "a;b" {
+		t.Errorf("expected txt 'a;b' in table mig_note - got '%s'", txt)
+	}
+
+	// nothing left to apply
+	n, err = m.Up()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if n != 0 {
+		t.Errorf("expected no migrations to be applied - got %d", n)
+	}
+
+	sl, err := m.Status()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(sl) != 2 || sl[0].Name != "wharf" || sl[1].Name != "notes" {
+		t.Fatalf("unexpected migration status: %v", sl)
+	}
+	for _, s := range sl {
+		if !s.Applied || s.AppliedAt.IsZero() {
+			t.Errorf("expected migration %d %s to be applied - got %v", s.Version, s.Name, s)
+		}
+	}
+
+	// revert the notes migration
+	err = m.Down()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if Handle.ExistsTable("mig_note") {
+		t.Errorf("expected table mig_note to have been dropped")
+	}
+	if !Handle.ExistsTable(wtn) {
+		t.Errorf("expected table %s to be retained", wtn)
+	}
+	sl, err = m.Status()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(sl) != 2 || !sl[0].Applied || sl[1].Applied {
+		t.Errorf("unexpected migration status following Down: %v", sl)
+	}
+
+	// a lock held by another runner blocks the migration
+	_, err = Handle.Exec("INSERT INTO sqac_migrations_lock (id, owner, locked_at) VALUES (?, ?, ?)", 1, "elsewhere", sl[0].AppliedAt)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	_, err = m.Up()
+	if err == nil {
+		t.Errorf("expected a migration lock error - got none")
+	}
+	if Handle.ExistsTable("mig_note") {
+		t.Errorf("table mig_note should not have been created while the lock was held")
+	}
+
+	err = m.Unlock()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	n, err = m.Up()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if n != 1 {
+		t.Errorf("expected 1 migration to be applied - got %d", n)
+	}
+
+	// revert everything
+	for i := 0; i < 2; i++ {
+		err = m.Down()
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+	}
+	if Handle.ExistsTable(wtn) || Handle.ExistsTable("mig_note") {
+		t.Errorf("expected tables %s and mig_note to have been dropped", wtn)
+	}
+}
+
+// TestMigrationsWithTableNaming
+//
+// Run a migration on a handle deriving prefixed table
+// names, and check that the history and lock tables keep
+// their fixed names.
+func TestMigrationsWithTableNaming(t *testing.T) {
+
+	type Wharf struct {
+		ID   uint64 `db:"id" sqac:"primary_key:inc"`
+		Name string `db:"name" sqac:"nullable:false"`
+	}
+
+	defer Handle.SetTableNaming(Handle.GetTableNaming())
+	Handle.SetTableNaming(common.TableNaming{Prefix: "app_"})
+	wtn := Handle.TableName(Wharf{})
+
+	for _, tn := range []string{"sqac_migrations", "sqac_migrations_lock"} {
+		if Handle.ExistsTable(tn) {
+			_, err := Handle.Exec("DROP TABLE " + tn)
+			if err != nil {
+				t.Errorf("%s", err.Error())
+			}
+		}
+	}
+
+	m, err := migrations.New(Handle, migrations.Migration{
+		Version: 20240101000000,
+		Name:    "wharf",
+		Up:      func(db sqac.PublicDB) error { return db.CreateTables(Wharf{}) },
+		Down:    func(db sqac.PublicDB) error { return db.DropTables(Wharf{}) },
+	})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	n, err := m.Up()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if n != 1 || !Handle.ExistsTable(wtn) {
+		t.Errorf("expected table %s to have been created by the migration", wtn)
+	}
+	if !Handle.ExistsTable("sqac_migrations") || !Handle.ExistsTable("sqac_migrations_lock") {
+		t.Errorf("expected the migration tables to keep their names")
+	}
+
+	err = m.Down()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if Handle.ExistsTable(wtn) {
+		t.Errorf("expected table %s to have been dropped", wtn)
+	}
+}
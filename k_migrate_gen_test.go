@@ -0,0 +1,355 @@
+package sqac_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac"
+	"github.com/1414C/sqac/common"
+	"github.com/1414C/sqac/migrations"
+)
+
+// readFile returns the content of file fn as a string.
+func readFile(t *testing.T, fn string) string {
+
+	b, err := os.ReadFile(fn)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	return string(b)
+}
+
+// TestGenerateMigration
+//
+// Generate a migration creating table pallet from the db,
+// apply it, then evolve the model and generate a second
+// migration adding a column and an index.  Apply the second
+// migration and revert both, checking that the data held in
+// table pallet survives the column changes.
+func TestGenerateMigration(t *testing.T) {
+
+	dir := t.TempDir()
+	tn := ""
+
+	for _, n := range []string{"sqac_migrations", "sqac_migrations_lock"} {
+		if Handle.ExistsTable(n) {
+			_, err := Handle.Exec("DROP TABLE " + n)
+			if err != nil {
+				t.Errorf("%s", err.Error())
+			}
+		}
+	}
+
+	{
+		type Pallet struct {
+			ID   uint64 `db:"id" sqac:"primary_key:inc"`
+			Code string `db:"code" sqac:"nullable:false;default:none"`
+		}
+
+		err := Handle.DropTables(Pallet{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		tn = common.GetTableName(Pallet{})
+
+		upFile, downFile, err := migrations.Generate(Handle, dir, "create_pallet", Pallet{})
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		if !strings.Contains(strings.ToLower(readFile(t, upFile)), "create table") {
+			t.Errorf("expected CREATE TABLE %s in %s - got %s", tn, upFile, readFile(t, upFile))
+		}
+		if !strings.Contains(strings.ToLower(readFile(t, downFile)), "drop table") {
+			t.Errorf("expected DROP TABLE %s in %s - got %s", tn, downFile, readFile(t, downFile))
+		}
+		if Handle.ExistsTable(tn) {
+			t.Errorf("table %s should not have been created by Generate", tn)
+		}
+	}
+
+	ms, err := migrations.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	m, err := migrations.New(Handle, ms...)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	_, err = m.Up()
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if !Handle.ExistsTable(tn) {
+		t.Fatalf("expected table %s to have been created by the generated migration", tn)
+	}
+	_, err = Handle.Exec("INSERT INTO " + tn + " (code) VALUES ('p1')")
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	type Pallet struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		Code   string `db:"code" sqac:"nullable:false;default:none"`
+		Weight int    `db:"weight" sqac:"nullable:false;default:0;index:non-unique"`
+	}
+
+	upFile, _, err := migrations.Generate(Handle, dir, "add_weight", Pallet{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if upFile == "" {
+		t.Errorf("expected a migration adding column weight to table %s", tn)
+	} else {
+		up := strings.ToLower(readFile(t, upFile))
+		if !strings.Contains(up, "weight") || !strings.Contains(up, "idx_"+tn+"_weight") {
+			t.Errorf("expected the addition of column weight and its index in %s - got %s", upFile, up)
+		}
+	}
+	if Handle.ExistsColumn(tn, "weight") {
+		t.Errorf("column weight should not have been added by Generate")
+	}
+
+	ms, err = migrations.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(ms) != 2 {
+		t.Fatalf("expected 2 generated migrations - got %d", len(ms))
+	}
+	m, err = migrations.New(Handle, ms...)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	n, err := m.Up()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if n != 1 {
+		t.Errorf("expected 1 migration to be applied - got %d", n)
+	}
+	if !Handle.ExistsColumn(tn, "weight") || !Handle.ExistsIndex(tn, "idx_"+tn+"_weight") {
+		t.Errorf("expected column weight and index idx_%s_weight on table %s", tn, tn)
+	}
+
+	var pallets []Pallet
+	_, err = Handle.GetEntitiesCP(&pallets, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(pallets) != 1 || pallets[0].Code != "p1" || pallets[0].Weight != 0 {
+		t.Errorf("unexpected content in table %s following the migration: %v", tn, pallets)
+	}
+
+	// revert the column addition
+	err = m.Down()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if Handle.ExistsColumn(tn, "weight") {
+		t.Errorf("column weight should have been removed from table %s", tn)
+	}
+	count := 0
+	err = Handle.Get(&count, "SELECT COUNT(*) FROM "+tn)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if count != 1 {
+		t.Errorf("expected 1 row in table %s following Down - got %d", tn, count)
+	}
+
+	// revert the table creation
+	err = m.Down()
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if Handle.ExistsTable(tn) {
+		t.Errorf("table %s should have been dropped", tn)
+	}
+}
+
+// TestGenerateMigrationFromSnapshot
+//
+// Generate migrations for table crate from a snapshot file
+// and check that the db is left untouched and the snapshot
+// is updated along the way.
+func TestGenerateMigrationFromSnapshot(t *testing.T) {
+
+	dir := t.TempDir()
+	snapFile := dir + "/schema.json"
+	tn := ""
+
+	{
+		type Crate struct {
+			ID    uint64 `db:"id" sqac:"primary_key:inc"`
+			Label string `db:"label" sqac:"nullable:true"`
+		}
+
+		err := Handle.DropTables(Crate{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		tn = common.GetTableName(Crate{})
+
+		upFile, _, err := migrations.GenerateFromSnapshot(Handle, snapFile, dir, "create_crate", Crate{})
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		if !strings.Contains(strings.ToLower(readFile(t, upFile)), "create table") {
+			t.Errorf("expected CREATE TABLE %s in %s - got %s", tn, upFile, readFile(t, upFile))
+		}
+
+		snap, err := migrations.ReadSnapshot(snapFile)
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		if _, ok := snap.Table(tn); !ok || snap.Flavor != Handle.GetDBDriverName() {
+			t.Errorf("expected table %s in the %s snapshot - got %v", tn, Handle.GetDBDriverName(), snap)
+		}
+
+		// the snapshot is up to date
+		upFile, _, err = migrations.GenerateFromSnapshot(Handle, snapFile, dir, "noop", Crate{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		if upFile != "" {
+			t.Errorf("expected no migration for an unchanged model - got %s", readFile(t, upFile))
+		}
+	}
+
+	type Crate struct {
+		ID    uint64 `db:"id" sqac:"primary_key:inc"`
+		Label string `db:"label" sqac:"nullable:true"`
+		Sku   string `db:"sku" sqac:"nullable:true"`
+	}
+
+	upFile, downFile, err := migrations.GenerateFromSnapshot(Handle, snapFile, dir, "add_sku", Crate{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if upFile == "" {
+		t.Fatalf("expected a migration adding column sku to table %s", tn)
+	}
+	up := strings.ToLower(readFile(t, upFile))
+	if !strings.Contains(up, "add column") || !strings.Contains(up, "sku") || strings.Contains(up, "create table") {
+		t.Errorf("expected the addition of column sku only in %s - got %s", upFile, up)
+	}
+	if readFile(t, downFile) == "" {
+		t.Errorf("expected statements reverting the addition of column sku in %s", downFile)
+	}
+
+	snap, err := migrations.ReadSnapshot(snapFile)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	ts, _ := snap.Table(tn)
+	if len(ts.Columns) != 3 {
+		t.Errorf("expected 3 columns for table %s in the snapshot - got %v", tn, ts.Columns)
+	}
+
+	if Handle.ExistsTable(tn) {
+		t.Errorf("table %s should not have been created from a snapshot", tn)
+	}
+}
+
+// migrationBody returns the content of migration file fn without its
+// header line, which carries the version of the migration.
+func migrationBody(t *testing.T, fn string) string {
+
+	s := readFile(t, fn)
+	return s[strings.Index(s, "\n")+1:]
+}
+
+// TestGenerateMigrationStable
+//
+// Generate the migrations of a model with several indexes
+// twice from the same snapshot, and check that the files
+// generated are identical apart from their versions.
+func TestGenerateMigrationStable(t *testing.T) {
+
+	type Tote struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		Name   string `db:"name" sqac:"nullable:false;index:unique"`
+		Region string `db:"region" sqac:"nullable:false;index:non-unique"`
+		Zone   string `db:"zone" sqac:"nullable:false;index:non-unique"`
+		Code   string `db:"code" sqac:"nullable:false;index:unique"`
+	}
+
+	dir := t.TempDir()
+	gen := func(snap, name string) (string, string) {
+		upFile, downFile, err := migrations.GenerateFromSnapshot(Handle, filepath.Join(dir, snap), dir, name, Tote{})
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		if upFile == "" {
+			t.Fatalf("expected a migration creating table tote")
+		}
+		return migrationBody(t, upFile), migrationBody(t, downFile)
+	}
+
+	up1, down1 := gen("first.json", "create_tote_1")
+	for i := 0; i < 10; i++ {
+		up, down := gen(fmt.Sprintf("next%d.json", i), fmt.Sprintf("create_tote_%d", i+2))
+		if up != up1 || down != down1 {
+			t.Fatalf("expected identical migrations - got:\n%s%s\nand:\n%s%s", up1, down1, up, down)
+		}
+	}
+
+	first := readFile(t, filepath.Join(dir, "first.json"))
+	next := readFile(t, filepath.Join(dir, "next0.json"))
+	if first != next {
+		t.Errorf("expected identical snapshots - got:\n%s\nand:\n%s", first, next)
+	}
+}
+
+// TestGenerateMigrationHDBProcedure
+//
+// Generate the migration of an HDB table with an auto-
+// incrementing key, load it back from its file and check
+// that the insert procedure is executed as one statement.
+func TestGenerateMigrationHDBProcedure(t *testing.T) {
+
+	type Punt struct {
+		ID   uint64 `db:"id" sqac:"primary_key:inc"`
+		Name string `db:"name" sqac:"nullable:false"`
+	}
+
+	h, err := sqac.CreateOffline("hdb", false)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	dir := t.TempDir()
+	upFile, _, err := migrations.GenerateFromSnapshot(h, filepath.Join(dir, "schema.json"), dir, "create_punt", Punt{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if upFile == "" {
+		t.Fatalf("expected a migration creating table punt")
+	}
+
+	ms, err := migrations.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(ms) != 1 {
+		t.Fatalf("expected 1 migration - got %d", len(ms))
+	}
+
+	var calls []recCall
+	err = ms[0].Up(recHandle(t, "hdb", &calls, recRow(nil)))
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	var proc []string
+	for _, c := range calls {
+		if strings.Contains(c.qs, "PROCEDURE") || strings.Contains(c.qs, "NEXTVAL") || strings.Contains(c.qs, "VALUES") {
+			proc = append(proc, c.qs)
+		}
+	}
+	if len(proc) != 1 || !strings.HasPrefix(proc[0], "CREATE OR REPLACE PROCEDURE INS_PUNT") || !strings.HasSuffix(proc[0], "END") {
+		t.Errorf("expected the insert procedure as one statement - got %q", proc)
+	}
+}
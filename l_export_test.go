@@ -0,0 +1,162 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac"
+)
+
+// TestExportDDL
+//
+// Export the DDL for a pair of related models in each flavor
+// without a connection to a db, and check that the script
+// covers the tables, the sequence start, the index and the
+// foreign-key.
+func TestExportDDL(t *testing.T) {
+
+	type Dock struct {
+		ID   uint64 `db:"id" sqac:"primary_key:inc;start:5000"`
+		Name string `db:"name" sqac:"nullable:false;index:unique"`
+	}
+
+	type Berth struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		DockID uint64 `db:"dock_id" sqac:"nullable:false;fkey:dock(id)"`
+	}
+
+	for _, fl := range []string{"postgres", "mysql", "sqlite", "mssql", "hdb"} {
+
+		script, err := sqac.ExportDDL(fl, Dock{}, Berth{})
+		if err != nil {
+			t.Errorf("%s: %s", fl, err.Error())
+			continue
+		}
+		s := strings.ToLower(script)
+
+		for _, want := range []string{"create", "table", "dock", "berth", "idx_dock_name", "foreign key", "references dock"} {
+			if !strings.Contains(s, want) {
+				t.Errorf("%s: expected '%s' in the exported DDL - got:\n%s", fl, want, script)
+			}
+		}
+		// sqlite records the last value issued rather than the start value
+		if !strings.Contains(s, "5000") && !strings.Contains(s, "4999") {
+			t.Errorf("%s: expected the sequence of table dock to start at 5000 - got:\n%s", fl, script)
+		}
+		if strings.Index(s, "berth") < strings.Index(s, "dock") {
+			t.Errorf("%s: expected table dock to be created before table berth - got:\n%s", fl, script)
+		}
+		for _, l := range strings.Split(strings.TrimSpace(script), "\n") {
+			if !strings.HasSuffix(l, ";") {
+				t.Errorf("%s: expected statement '%s' to be terminated by a semicolon", fl, l)
+			}
+		}
+	}
+
+	_, err := sqac.ExportDDL("db3", Dock{})
+	if err == nil {
+		t.Errorf("expected an unsupported flavor error - got none")
+	}
+
+	// an offline handle does not reach a db
+	h, err := sqac.CreateOffline("postgres", false)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	_, err = h.Exec("DELETE FROM dock")
+	if err == nil {
+		t.Errorf("expected an error for a statement executed through an offline handle - got none")
+	}
+}
+
+// TestExportDDLStable
+//
+// Export the DDL of a model with several indexes and
+// constraints repeatedly, and check that the scripts are
+// byte-identical, so that a checked-in script does not
+// change from one export to the next.
+func TestExportDDLStable(t *testing.T) {
+
+	type Quayside struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		Name   string `db:"name" sqac:"nullable:false;index:unique"`
+		Region string `db:"region" sqac:"nullable:false;index:idx_quayside_region_zone"`
+		Zone   string `db:"zone" sqac:"nullable:false;index:idx_quayside_region_zone"`
+		Depth  int    `db:"depth" sqac:"nullable:false;default:0;index:non-unique;check:depth >= 0"`
+		Length int    `db:"length" sqac:"nullable:false;default:0;index:non-unique;check:length < 1000"`
+		Code   string `db:"code" sqac:"nullable:false;index:unique"`
+	}
+
+	for _, fl := range []string{"postgres", "mysql", "sqlite", "mssql", "hdb"} {
+
+		first, err := sqac.ExportDDL(fl, Quayside{})
+		if err != nil {
+			t.Errorf("%s: %s", fl, err.Error())
+			continue
+		}
+		for i := 0; i < 20; i++ {
+			script, err := sqac.ExportDDL(fl, Quayside{})
+			if err != nil {
+				t.Fatalf("%s: %s", fl, err.Error())
+			}
+			if script != first {
+				t.Fatalf("%s: expected identical scripts - got:\n%s\nand:\n%s", fl, first, script)
+			}
+		}
+	}
+}
+
+// TestExportDDLSchema
+//
+// Export the DDL of models placed in a schema via their
+// struct-level tag, and check that the names derived from
+// the table-names (keys, checks, indexes, sequences and
+// foreign-keys) are not schema-qualified.
+func TestExportDDLSchema(t *testing.T) {
+
+	type Widget struct {
+		_      struct{} `sqac:"schema:acme"`
+		ID     uint64   `db:"id" sqac:"primary_key:inc"`
+		Name   string   `db:"name" sqac:"nullable:false;default:none;index:unique"`
+		Price  int      `db:"price" sqac:"nullable:false;default:0;check:price >= 0"`
+		Status string   `db:"status" sqac:"nullable:false;default:new;enum:new|old"`
+	}
+
+	type Gadget struct {
+		_        struct{} `sqac:"schema:acme"`
+		ID       uint64   `db:"id" sqac:"primary_key:inc"`
+		WidgetID uint64   `db:"widget_id" sqac:"nullable:false;fkey:widget(id)"`
+	}
+
+	wants := map[string][]string{
+		"postgres": {"constraint widget_pkey primary key", "constraint ck_widget_price check", "constraint ck_widget_status_enum check",
+			"index idx_widget_name on acme.widget", "constraint fk_gadget_widget_id foreign key"},
+		"mysql": {"constraint ck_widget_price check", "index idx_widget_name on acme.widget", "constraint fk_gadget_widget_id foreign key"},
+		"mssql": {"constraint ck_widget_price check", "constraint ck_widget_status_enum check",
+			"index idx_widget_name on acme.widget", "constraint fk_gadget_widget_id foreign key"},
+		"hdb": {"constraint ck_widget_price check", "constraint ck_widget_status_enum check", "sequence acme.seq_widget_id",
+			"procedure acme.ins_widget", "index idx_widget_name on acme.widget", "constraint fk_gadget_widget_id foreign key"},
+	}
+
+	// sqlite schemas are attached database files, which are not
+	// available to an offline handle
+	for _, fl := range []string{"postgres", "mysql", "mssql", "hdb"} {
+
+		script, err := sqac.ExportDDL(fl, Widget{}, Gadget{})
+		if err != nil {
+			t.Errorf("%s: %s", fl, err.Error())
+			continue
+		}
+		s := strings.ToLower(script)
+		for _, want := range wants[fl] {
+			if !strings.Contains(s, want) {
+				t.Errorf("%s: expected '%s' in the exported DDL - got:\n%s", fl, want, script)
+			}
+		}
+		for _, bad := range []string{"acme.widget_", "acme.gadget_", "_acme."} {
+			if strings.Contains(s, bad) {
+				t.Errorf("%s: expected no schema-qualified derived names in the exported DDL - got:\n%s", fl, script)
+			}
+		}
+	}
+}
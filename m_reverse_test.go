@@ -0,0 +1,129 @@
+package sqac_test
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1414C/sqac"
+	"github.com/1414C/sqac/common"
+)
+
+// TestGenerateModels
+//
+// Create tables quay and mooring, read them back as go
+// models and check that the generated source carries the
+// sqac tags describing the tables.
+func TestGenerateModels(t *testing.T) {
+
+	type Quay struct {
+		ID       uint64     `db:"id" sqac:"primary_key:inc"`
+		Name     string     `db:"name" sqac:"nullable:false;index:unique"`
+		Region   string     `db:"region" sqac:"nullable:false;default:north;index:idx_quay_region_zone"`
+		Zone     int        `db:"zone" sqac:"nullable:false;default:0;index:idx_quay_region_zone"`
+		Note     *string    `db:"note" sqac:"nullable:true"`
+		OpenedAt *time.Time `db:"opened_at" sqac:"nullable:true"`
+	}
+
+	type Mooring struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		QuayID uint64 `db:"quay_id" sqac:"nullable:false;fkey:quay(id)"`
+	}
+
+	err := Handle.DropTables(Mooring{}, Quay{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Quay{}, Mooring{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Mooring{}, Quay{})
+
+	qtn := common.GetTableName(Quay{})
+	mtn := common.GetTableName(Mooring{})
+
+	src, err := Handle.GenerateModels("models", qtn, mtn)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	s := string(src)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "models.go", src, 0)
+	if err != nil {
+		t.Errorf("generated source does not parse: %s\n%s", err.Error(), s)
+	}
+
+	for _, want := range []string{
+		"package models",
+		"import \"time\"",
+		"type Quay struct",
+		"type Mooring struct",
+		"`json:\"id\" db:\"id\" sqac:\"primary_key:inc\"`",
+		"Name string `json:\"name\" db:\"name\" sqac:\"nullable:false;index:unique\"`",
+		"Region string `json:\"region\" db:\"region\" sqac:\"nullable:false;default:north;index:idx_quay_region_zone\"`",
+		"Note *string `json:\"note\" db:\"note\" sqac:\"nullable:true\"`",
+		"OpenedAt *time.Time",
+		"`json:\"quay_id\" db:\"quay_id\" sqac:\"nullable:false;fkey:quay(id)\"`",
+	} {
+		if !strings.Contains(strings.Join(strings.Fields(s), " "), want) {
+			t.Errorf("expected %s in the generated models - got:\n%s", want, s)
+		}
+	}
+
+	_, err = Handle.GenerateModels("models", "no_such_table")
+	if err == nil {
+		t.Errorf("expected an error for a missing table - got none")
+	}
+}
+
+// TestGenerateModelTypes
+//
+// Create table ledger with sized, decimal, date and time
+// columns and check that the generated model maps them back
+// onto the sqac types and tags.
+func TestGenerateModelTypes(t *testing.T) {
+
+	type Ledger struct {
+		ID     uint64          `db:"id" sqac:"primary_key:inc"`
+		Code   string          `db:"code" sqac:"nullable:false;size:8"`
+		Amount sqac.Decimal    `db:"amount" sqac:"nullable:false;precision:12;scale:2"`
+		Day    sqac.Date       `db:"day" sqac:"nullable:false"`
+		Opens  *sqac.TimeOfDay `db:"opens" sqac:"nullable:true"`
+	}
+
+	err := Handle.DropTables(Ledger{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Ledger{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Ledger{})
+
+	src, err := Handle.GenerateModels("models", common.GetTableName(Ledger{}))
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	s := strings.Join(strings.Fields(string(src)), " ")
+
+	_, err = parser.ParseFile(token.NewFileSet(), "models.go", src, 0)
+	if err != nil {
+		t.Errorf("generated source does not parse: %s\n%s", err.Error(), src)
+	}
+
+	for _, want := range []string{
+		"import \"github.com/1414C/sqac\"",
+		"Code string `json:\"code\" db:\"code\" sqac:\"nullable:false;size:8\"`",
+		"Amount sqac.Decimal `json:\"amount\" db:\"amount\" sqac:\"nullable:false;precision:12;scale:2\"`",
+		"Day sqac.Date",
+		"Opens *sqac.TimeOfDay",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected %s in the generated model - got:\n%s", want, src)
+		}
+	}
+}
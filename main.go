@@ -135,3 +135,47 @@ func Create(flavor string, logFlag bool, dbLogFlag bool, connectionString string
 	}
 	return handle
 }
+
+// CreateOffline returns a handle for the specified flavor that is not connected
+// to a db.  The Plan* methods of an offline handle render their statements as
+// if the db held no tables, which allows DDL to be generated for a flavor without
+// access to a db of that flavor.  Any attempt to read from or write to the db
+// through an offline handle fails.
+func CreateOffline(flavor string, logFlag bool) (handle PublicDB, err error) {
+
+	var bf *BaseFlavor
+
+	switch flavor {
+	case "postgres":
+		pgh := new(PostgresFlavor)
+		handle, bf = pgh, &pgh.BaseFlavor
+		handle.SetDB(offlineDB("postgres"))
+
+	case "mysql":
+		myh := new(MySQLFlavor)
+		handle, bf = myh, &myh.BaseFlavor
+		handle.SetDB(offlineDB("mysql"))
+
+	case "sqlite":
+		sqh := new(SQLiteFlavor)
+		handle, bf = sqh, &sqh.BaseFlavor
+		handle.SetDB(offlineDB("sqlite3"))
+
+	case "mssql":
+		msh := new(MSSQLFlavor)
+		handle, bf = msh, &msh.BaseFlavor
+		handle.SetDB(offlineDB("mssql"))
+
+	case "hdb":
+		hdh := new(HDBFlavor)
+		handle, bf = hdh, &hdh.BaseFlavor
+		handle.SetDB(offlineDB("hdb"))
+
+	default:
+		return nil, fmt.Errorf("unsupported db flavor '%s'", flavor)
+	}
+
+	bf.offline = true
+	handle.Log(logFlag)
+	return handle, nil
+}
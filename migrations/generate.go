@@ -0,0 +1,149 @@
+package migrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/1414C/sqac"
+)
+
+// Generate compares the models with their tables in the db referenced by
+// db, and writes the statements required to migrate the tables to the
+// models into a pair of timestamped SQL files in directory dir:
+// <version>_<name>.up.sql and <version>_<name>.down.sql.  The files can
+// be reviewed and checked in, and are read by LoadDir.  The statements
+// are rendered for the flavor of db, and the destructive-change policy
+// of db governs the dropping of columns.  The paths of the files are
+// returned; if the tables already match the models, no files are
+// written and empty paths are returned.
+func Generate(db sqac.PublicDB, dir, name string, models ...interface{}) (string, string, error) {
+
+	up, down, err := db.PlanMigration(nil, models...)
+	if err != nil {
+		return "", "", err
+	}
+	return writeFiles(dir, name, "db", db.GetDBDriverName(), up, down)
+}
+
+// GenerateFromSnapshot works like Generate, but compares the models with
+// the snapshot held in file snapFile rather than with the db.  db is used
+// to render the statements for its flavor.  Once the migration files have
+// been written, the snapshot file is updated to reflect the models, so
+// that the next migration can be generated from it.  If snapFile does not
+// exist, the tables of all models are considered to be new.
+func GenerateFromSnapshot(db sqac.PublicDB, snapFile, dir, name string, models ...interface{}) (string, string, error) {
+
+	prev, err := ReadSnapshot(snapFile)
+	if err != nil {
+		return "", "", err
+	}
+
+	up, down, err := db.PlanMigration(&prev, models...)
+	if err != nil {
+		return "", "", err
+	}
+	upFile, downFile, err := writeFiles(dir, name, "snapshot "+filepath.Base(snapFile), db.GetDBDriverName(), up, down)
+	if err != nil || upFile == "" {
+		return upFile, downFile, err
+	}
+
+	next, err := db.SnapshotTables(models...)
+	if err != nil {
+		return upFile, downFile, err
+	}
+	return upFile, downFile, WriteSnapshot(snapFile, mergeSnapshots(prev, next))
+}
+
+// ReadSnapshot reads a snapshot from file fn.  An empty snapshot is
+// returned if the file does not exist.
+func ReadSnapshot(fn string) (sqac.Snapshot, error) {
+
+	var snap sqac.Snapshot
+	b, err := os.ReadFile(fn)
+	if os.IsNotExist(err) {
+		return snap, nil
+	}
+	if err != nil {
+		return snap, err
+	}
+	err = json.Unmarshal(b, &snap)
+	if err != nil {
+		return snap, fmt.Errorf("unable to read snapshot %s: %v", fn, err)
+	}
+	return snap, nil
+}
+
+// WriteSnapshot writes snapshot snap to file fn.
+func WriteSnapshot(fn string, snap sqac.Snapshot) error {
+
+	b, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fn, append(b, '\n'), 0644)
+}
+
+// mergeSnapshots returns the previous snapshot with the tables in next
+// replacing or added to those in prev.  Tables that are not part of next
+// are retained.
+func mergeSnapshots(prev, next sqac.Snapshot) sqac.Snapshot {
+
+	merged := sqac.Snapshot{Flavor: next.Flavor}
+	for _, t := range prev.Tables {
+		if nt, ok := next.Table(t.Name); ok {
+			t = nt
+		}
+		merged.Tables = append(merged.Tables, t)
+	}
+	for _, t := range next.Tables {
+		if _, ok := prev.Table(t.Name); !ok {
+			merged.Tables = append(merged.Tables, t)
+		}
+	}
+	return merged
+}
+
+// writeFiles writes the up and down statements to a pair of migration
+// files.  The version is taken from the current UTC time, and is bumped
+// if a migration with the same version exists in dir.
+func writeFiles(dir, name, source, flavor string, up, down []string) (string, string, error) {
+
+	if len(up) == 0 {
+		return "", "", nil
+	}
+	if name == "" || strings.ContainsAny(name, `/\`) || !sqlFileRegexp.MatchString("1_"+name+".up.sql") {
+		return "", "", fmt.Errorf("invalid migration name '%s'", name)
+	}
+
+	t := time.Now().UTC()
+	version := ""
+	for {
+		version = t.Format("20060102150405")
+		existing, err := filepath.Glob(filepath.Join(dir, version+"_*.sql"))
+		if err != nil {
+			return "", "", err
+		}
+		if len(existing) == 0 {
+			break
+		}
+		t = t.Add(time.Second)
+	}
+
+	header := fmt.Sprintf("-- %s %s generated from %s for %s\n", version, name, source, flavor)
+	upFile := filepath.Join(dir, version+"_"+name+".up.sql")
+	downFile := filepath.Join(dir, version+"_"+name+".down.sql")
+
+	err := os.WriteFile(upFile, []byte(header+sqac.JoinStatements(up)), 0644)
+	if err != nil {
+		return "", "", err
+	}
+	err = os.WriteFile(downFile, []byte(header+sqac.JoinStatements(down)), 0644)
+	if err != nil {
+		return "", "", err
+	}
+	return upFile, downFile, nil
+}
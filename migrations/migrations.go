@@ -0,0 +1,331 @@
+// Package migrations provides ordered, versioned schema migrations for
+// databases accessed through a sqac.PublicDB handle.  Migrations may be
+// written as go functions, or loaded from up/down SQL files.  Applied
+// migrations are recorded in table sqac_migrations, and concurrent
+// runners are kept apart by way of a lock record held in table
+// sqac_migrations_lock for the duration of a run.
+//
+// SQL migration files can be generated from the differences between a
+// set of models and the db, or a previously stored snapshot, so that
+// schema changes may be reviewed and checked in rather than being
+// applied implicitly by AlterTables.
+package migrations
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/1414C/sqac"
+)
+
+// Migration is a single versioned schema change.  Version determines the
+// order in which migrations are applied and must be unique; a timestamp
+// such as 20240131120000 is a good choice.  Up and Down receive the handle
+// passed to the Migrator, so that the flavor-specific DDL operations such
+// as CreateTables and AlterTables may be used alongside plain SQL.  Down
+// may be nil if the migration cannot be reverted.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      func(db sqac.PublicDB) error
+	Down    func(db sqac.PublicDB) error
+}
+
+// Status reports the state of a migration.  Migrations that are recorded
+// in the history table but are unknown to the Migrator are reported with
+// Applied = true and Unknown = true.
+type Status struct {
+	Version   uint64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	Unknown   bool
+}
+
+// historyRecord is the model of the migration history table,
+// sqac_migrations.
+type historyRecord struct {
+	Version   uint64    `db:"version" sqac:"primary_key:"`
+	Name      string    `db:"name" sqac:"nullable:false"`
+	AppliedAt time.Time `db:"applied_at" sqac:"nullable:false"`
+}
+
+// TableName fixes the name of the history table, so that it is not
+// subject to the table naming options of the handle.
+func (historyRecord) TableName() string {
+	return historyTable
+}
+
+// lockRecord is the model of the migration lock table,
+// sqac_migrations_lock.  The table holds a single record while a
+// Migrator is running.
+type lockRecord struct {
+	ID       int       `db:"id" sqac:"primary_key:"`
+	Owner    string    `db:"owner" sqac:"nullable:false"`
+	LockedAt time.Time `db:"locked_at" sqac:"nullable:false"`
+}
+
+// TableName fixes the name of the lock table, so that it is not subject
+// to the table naming options of the handle.
+func (lockRecord) TableName() string {
+	return lockTable
+}
+
+const (
+	historyTable = "sqac_migrations"
+	lockTable    = "sqac_migrations_lock"
+	lockID       = 1
+)
+
+// Migrator applies and reverts a set of migrations on the db referenced
+// by a sqac.PublicDB handle.
+type Migrator struct {
+	db         sqac.PublicDB
+	migrations []Migration
+	owner      string
+}
+
+// New returns a Migrator for the provided handle and migrations.  The
+// migrations are sorted by version; duplicate versions and migrations
+// without an Up function are rejected.
+func New(db sqac.PublicDB, migrations ...Migration) (*Migrator, error) {
+
+	ms := make([]Migration, len(migrations))
+	copy(ms, migrations)
+	sort.Slice(ms, func(i, j int) bool { return ms[i].Version < ms[j].Version })
+
+	for i, m := range ms {
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d %s has no Up function", m.Version, m.Name)
+		}
+		if i > 0 && ms[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicate migration version %d (%s, %s)", m.Version, ms[i-1].Name, m.Name)
+		}
+	}
+
+	host, _ := os.Hostname()
+	return &Migrator{
+		db:         db,
+		migrations: ms,
+		owner:      fmt.Sprintf("%s:%d", host, os.Getpid()),
+	}, nil
+}
+
+// Up applies all pending migrations in version order and returns the
+// number of migrations that were applied.  Each migration is recorded
+// in the history table as soon as it has been applied, so a failed run
+// may be resumed by calling Up again once the cause has been fixed.
+func (m *Migrator) Up() (int, error) {
+
+	err := m.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock()
+
+	applied, err := m.history()
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, mg := range m.migrations {
+		if _, ok := applied[mg.Version]; ok {
+			continue
+		}
+
+		log.Printf("applying migration %d %s\n", mg.Version, mg.Name)
+		err = mg.Up(m.db)
+		if err != nil {
+			return n, fmt.Errorf("migration %d %s failed: %v", mg.Version, mg.Name, err)
+		}
+
+		qs := "INSERT INTO " + m.historyTable() + " (version, name, applied_at) VALUES (?, ?, ?);"
+		_, err = m.db.Exec(qs, mg.Version, mg.Name, time.Now().UTC())
+		if err != nil {
+			return n, fmt.Errorf("migration %d %s was applied, but could not be recorded: %v", mg.Version, mg.Name, err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// Down reverts the most recently applied migration.  An error is returned
+// if the migration is unknown to the Migrator or has no Down function.
+// Down does nothing if no migrations have been applied.
+func (m *Migrator) Down() error {
+
+	err := m.lock()
+	if err != nil {
+		return err
+	}
+	defer m.unlock()
+
+	applied, err := m.history()
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		return nil
+	}
+
+	var last uint64
+	for v := range applied {
+		if v > last {
+			last = v
+		}
+	}
+
+	var mg *Migration
+	for i := range m.migrations {
+		if m.migrations[i].Version == last {
+			mg = &m.migrations[i]
+			break
+		}
+	}
+	if mg == nil {
+		return fmt.Errorf("applied migration %d %s is unknown - unable to revert", last, applied[last].Name)
+	}
+	if mg.Down == nil {
+		return fmt.Errorf("migration %d %s has no Down function", mg.Version, mg.Name)
+	}
+
+	log.Printf("reverting migration %d %s\n", mg.Version, mg.Name)
+	err = mg.Down(m.db)
+	if err != nil {
+		return fmt.Errorf("reverting migration %d %s failed: %v", mg.Version, mg.Name, err)
+	}
+
+	qs := "DELETE FROM " + m.historyTable() + " WHERE version = ?;"
+	_, err = m.db.Exec(qs, mg.Version)
+	if err != nil {
+		return fmt.Errorf("migration %d %s was reverted, but the history could not be updated: %v", mg.Version, mg.Name, err)
+	}
+	return nil
+}
+
+// Status reports the state of all known and applied migrations in
+// version order.
+func (m *Migrator) Status() ([]Status, error) {
+
+	applied, err := m.history()
+	if err != nil {
+		return nil, err
+	}
+
+	sl := make([]Status, 0, len(m.migrations))
+	for _, mg := range m.migrations {
+		st := Status{Version: mg.Version, Name: mg.Name}
+		if h, ok := applied[mg.Version]; ok {
+			st.Applied = true
+			st.AppliedAt = h.AppliedAt
+			delete(applied, mg.Version)
+		}
+		sl = append(sl, st)
+	}
+
+	for _, h := range applied {
+		sl = append(sl, Status{Version: h.Version, Name: h.Name, Applied: true, AppliedAt: h.AppliedAt, Unknown: true})
+	}
+	sort.Slice(sl, func(i, j int) bool { return sl[i].Version < sl[j].Version })
+	return sl, nil
+}
+
+// Unlock removes the migration lock regardless of its owner.  This is
+// intended for the recovery from a runner that terminated without
+// releasing the lock.
+func (m *Migrator) Unlock() error {
+
+	err := m.ensureTables()
+	if err != nil {
+		return err
+	}
+	_, err = m.db.Exec("DELETE FROM "+m.lockTable()+" WHERE id = ?;", lockID)
+	return err
+}
+
+// historyTable returns the name of the history table on the handle of the
+// Migrator, qualified by the schema or tenant of the handle.
+func (m *Migrator) historyTable() string {
+	return m.db.TableName(historyRecord{})
+}
+
+// lockTable returns the name of the lock table on the handle of the
+// Migrator, qualified by the schema or tenant of the handle.
+func (m *Migrator) lockTable() string {
+	return m.db.TableName(lockRecord{})
+}
+
+// ensureTables creates the history and lock tables if they do not exist.
+func (m *Migrator) ensureTables() error {
+
+	if !m.db.ExistsTable(m.historyTable()) {
+		err := m.db.CreateTables(historyRecord{})
+		if err != nil {
+			return err
+		}
+	}
+	if !m.db.ExistsTable(m.lockTable()) {
+		err := m.db.CreateTables(lockRecord{})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// history reads the applied migrations from the history table.
+func (m *Migrator) history() (map[uint64]historyRecord, error) {
+
+	err := m.ensureTables()
+	if err != nil {
+		return nil, err
+	}
+
+	var recs []historyRecord
+	err = m.db.Select(&recs, "SELECT version, name, applied_at FROM "+m.historyTable()+" ORDER BY version;")
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint64]historyRecord)
+	for _, r := range recs {
+		applied[r.Version] = r
+	}
+	return applied, nil
+}
+
+// lock acquires the migration lock by inserting the lock record.  The
+// primary-key of the lock table prevents a second runner from doing the
+// same.
+func (m *Migrator) lock() error {
+
+	err := m.ensureTables()
+	if err != nil {
+		return err
+	}
+
+	qs := "INSERT INTO " + m.lockTable() + " (id, owner, locked_at) VALUES (?, ?, ?);"
+	_, err = m.db.Exec(qs, lockID, m.owner, time.Now().UTC())
+	if err != nil {
+		var held []lockRecord
+		rErr := m.db.Select(&held, "SELECT id, owner, locked_at FROM "+m.lockTable()+" WHERE id = ?;", lockID)
+		if rErr == nil && len(held) > 0 {
+			return fmt.Errorf("migrations are locked by %s since %v", held[0].Owner, held[0].LockedAt)
+		}
+		return fmt.Errorf("unable to acquire the migration lock: %v", err)
+	}
+	return nil
+}
+
+// unlock releases the migration lock held by the Migrator.
+func (m *Migrator) unlock() {
+
+	_, err := m.db.Exec("DELETE FROM "+m.lockTable()+" WHERE id = ? AND owner = ?;", lockID, m.owner)
+	if err != nil {
+		log.Printf("WARNING: unable to release the migration lock: %v\n", err)
+	}
+}
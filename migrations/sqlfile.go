@@ -0,0 +1,216 @@
+package migrations
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/1414C/sqac"
+)
+
+// sqlFileRegexp matches migration file names of the form
+// <version>_<name>.up.sql and <version>_<name>.down.sql.
+var sqlFileRegexp = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// LoadDir reads the SQL migration files in directory dir.  See LoadFS.
+func LoadDir(dir string) ([]Migration, error) {
+	return LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadFS reads the SQL migration files in directory dir of fsys and
+// returns them as migrations.  Files are expected to be named
+// <version>_<name>.up.sql, with an optional <version>_<name>.down.sql
+// holding the statements to revert the migration.  Other files are
+// ignored.  The statements in a file are separated by semicolons (see
+// SplitStatements) and are executed as a single transaction via ProcessTransaction.  Note
+// that some dbs (mysql, hdb) commit DDL implicitly, in which case a
+// failed migration may be partially applied.
+func LoadFS(fsys fs.FS, dir string) ([]Migration, error) {
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	mm := make(map[uint64]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		sm := sqlFileRegexp.FindStringSubmatch(e.Name())
+		if sm == nil {
+			continue
+		}
+
+		v, err := strconv.ParseUint(sm[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in file %s: %v", e.Name(), err)
+		}
+
+		b, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := mm[v]
+		if !ok {
+			m = &Migration{Version: v, Name: sm[2]}
+			mm[v] = m
+		}
+		if m.Name != sm[2] {
+			return nil, fmt.Errorf("migration version %d is used by %s and %s", v, m.Name, sm[2])
+		}
+
+		f := sqlFunc(SplitStatements(string(b)))
+		switch sm[3] {
+		case "up":
+			m.Up = f
+		case "down":
+			m.Down = f
+		}
+	}
+
+	ms := make([]Migration, 0, len(mm))
+	for _, m := range mm {
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d %s has a down file, but no up file", m.Version, m.Name)
+		}
+		ms = append(ms, *m)
+	}
+	sort.Slice(ms, func(i, j int) bool { return ms[i].Version < ms[j].Version })
+	return ms, nil
+}
+
+// sqlFunc returns a migration function that executes the statements as
+// a single transaction.
+func sqlFunc(stmts []string) func(db sqac.PublicDB) error {
+	return func(db sqac.PublicDB) error {
+		if len(stmts) == 0 {
+			return nil
+		}
+		return db.ProcessTransaction(stmts)
+	}
+}
+
+// SplitStatements splits a SQL script into its statements on semicolons
+// that are not part of a quoted string, quoted identifier, comment or
+// BEGIN ... END block, so that the body of a procedure remains part of
+// its CREATE statement.  Empty statements are discarded, and the
+// terminating semicolons are not included.
+func SplitStatements(script string) []string {
+
+	stmts := make([]string, 0)
+	var sb strings.Builder
+	code := false // statement contains more than comments and white-space
+	depth := 0    // nesting level of BEGIN ... END and CASE ... END blocks
+
+	add := func() {
+		if code {
+			stmts = append(stmts, strings.TrimSpace(sb.String()))
+		}
+		sb.Reset()
+		code = false
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// copy through the closing quote; doubled quotes are escapes
+			// and simply reopen the quoted section.
+			j := i + 1
+			for j < len(script) && script[j] != c {
+				j++
+			}
+			if j >= len(script) {
+				j = len(script) - 1
+			}
+			sb.WriteString(script[i : j+1])
+			code = true
+			i = j
+		case c == '-' && i+1 < len(script) && script[i+1] == '-':
+			j := strings.IndexByte(script[i:], '\n')
+			if j == -1 {
+				j = len(script) - i
+			}
+			sb.WriteString(script[i : i+j])
+			i += j - 1
+		case c == '/' && i+1 < len(script) && script[i+1] == '*':
+			j := strings.Index(script[i+2:], "*/")
+			if j == -1 {
+				j = len(script) - i - 2
+			} else {
+				j += 2
+			}
+			sb.WriteString(script[i : i+2+j])
+			i += 2 + j - 1
+		case c == ';' && depth > 0:
+			sb.WriteByte(c)
+		case c == ';':
+			add()
+		case isWordByte(c) && (i == 0 || !isWordByte(script[i-1])):
+			j := i
+			for j < len(script) && isWordByte(script[j]) {
+				j++
+			}
+			switch strings.ToUpper(script[i:j]) {
+			case "BEGIN":
+				// BEGIN [TRANSACTION | WORK | TRAN] is a statement of its own
+				switch nextWord(script, j) {
+				case ";", "", "TRANSACTION", "WORK", "TRAN":
+				default:
+					depth++
+				}
+			case "CASE":
+				depth++
+			case "END":
+				// END IF, END LOOP, ... close blocks that have no BEGIN
+				switch nextWord(script, j) {
+				case "IF", "LOOP", "WHILE", "FOR", "REPEAT":
+				default:
+					if depth > 0 {
+						depth--
+					}
+				}
+			}
+			sb.WriteString(script[i:j])
+			code = true
+			i = j - 1
+		default:
+			sb.WriteByte(c)
+			if !unicode.IsSpace(rune(c)) {
+				code = true
+			}
+		}
+	}
+	add()
+	return stmts
+}
+
+// isWordByte reports whether c may be part of a SQL keyword or identifier.
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c == '#' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// nextWord returns the upper-cased word that follows position i of script,
+// ";" if the next non-space character is a semicolon, or "" otherwise.
+func nextWord(script string, i int) string {
+
+	for i < len(script) && unicode.IsSpace(rune(script[i])) {
+		i++
+	}
+	if i < len(script) && script[i] == ';' {
+		return ";"
+	}
+	j := i
+	for j < len(script) && isWordByte(script[j]) {
+		j++
+	}
+	return strings.ToUpper(script[i:j])
+}
@@ -1,6 +1,7 @@
 package sqac
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
 	"reflect"
@@ -61,6 +62,13 @@ func (msf *MSSQLFlavor) createTables(calledFromAlter bool, i ...interface{}) ([]
 
 	// get the list of table Model{}s
 	di := i[0].([]interface{})
+
+	// create the schemas of the tables if need be
+	err := msf.createTableSchemas(msf, di...)
+	if err != nil {
+		return nil, err
+	}
+
 	for t, ent := range di {
 
 		ftr := reflect.TypeOf(ent)
@@ -69,14 +77,15 @@ func (msf *MSSQLFlavor) createTables(calledFromAlter bool, i ...interface{}) ([]
 		}
 
 		// determine the table name
-		tn := common.GetTableName(di[t])
+		tn := msf.TableName(di[t])
 		if tn == "" {
 			return nil, fmt.Errorf("unable to determine table name in myf.CreateTables")
 		}
 
 		// if the table is found to exist, skip the creation
-		// and move on to the next table in the list.
-		if msf.ExistsTable(tn) {
+		// and move on to the next table in the list.  tables
+		// dropped earlier in a plan are considered absent.
+		if !msf.absentInPlan(tn) && msf.ExistsTable(tn) {
 			if msf.log {
 				log.Printf("createTable - table %s exists - skipping...\n", tn)
 			}
@@ -88,15 +97,15 @@ func (msf *MSSQLFlavor) createTables(calledFromAlter bool, i ...interface{}) ([]
 		msf.QsLog(tc.tblSchema)
 
 		// create the table on the db
-		msf.db.MustExec(tc.tblSchema)
+		msf.mustExec(tc.tblSchema)
 		for _, sq := range tc.seq {
 			start, _ := strconv.Atoi(sq.Value)
 			msf.AlterSequenceStart(sq.Name, start)
 		}
 
 		// create the table indices
-		for k, in := range tc.ind {
-			msf.CreateIndex(k, in)
+		for _, k := range indexNames(tc.ind) {
+			msf.CreateIndex(k, tc.ind[k])
 		}
 
 		// add foreign-key information to the buffer
@@ -143,8 +152,10 @@ func (msf *MSSQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 	pKeys := ""
 	var sequences []common.SqacPair
 	indexes := make(map[string]IndexInfo)
+	constraints := make(map[string]ConstraintInfo)
+	columns := make([]ColComponents, 0)
 	fKeys := make([]FKeyInfo, 0)
-	tableSchema := "CREATE TABLE " + qt + tn + qt + " ("
+	tableSchema := "CREATE TABLE " + quotedTableName(tn, qt) + " ("
 
 	// get a list of the field names, go-types and db attributes.
 	// TagReader is a common function across db-flavors. For
@@ -196,15 +207,39 @@ func (msf *MSSQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 
 		case "string":
 			col.fType = "varchar(255)" //
+			if fd.TagValue("primary_key") == "uuid" {
+				// the driver reads uniqueidentifier columns as raw bytes, so
+				// uuid keys are held in their text form
+				col.fType = "char(36)"
+			}
+
+		case common.DecimalType:
+			col.fType = decimalType("decimal", fd)
+
+		case common.JSONType, common.ArrayType:
+			col.fType = "nvarchar(max)"
+
+		case common.ValuerType:
+			col.fType = "varchar(255)"
+
+		case common.DateType:
+			col.fType = "date"
+
+		case common.TimeOfDayType:
+			col.fType = "time"
 
 		case "time.Time":
 			col.fType = "datetime2"
 
+		case "[]byte":
+			col.fType = "varbinary(max)"
+
 		default:
 			err := fmt.Errorf("go type %s is not presently supported", fldef[idx].FType)
 			panic(err)
 		}
 		fldef[idx].FType = col.fType
+		col.uType = userType(fd, "varchar")
 
 		// read sqac tag pairs and apply
 		seqName := ""
@@ -219,11 +254,6 @@ func (msf *MSSQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 					pKeys = pKeys + " " + qt + fd.FName + qt + ","
 
 					if p.Value == "inc" {
-						// warn that user-specified db_type type will be ignored
-						if col.uType != "" {
-							log.Printf("WARNING: %s auto-incrementing primary-key field %s has user-specified db_type: %s  user-type is ignored. \n", common.GetTableName(ent), col.fName, col.uType)
-							col.uType = ""
-						}
 						col.fAutoInc = true
 					}
 
@@ -238,7 +268,7 @@ func (msf *MSSQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 					}
 
 				case "default":
-					if fd.UnderGoType == "string" {
+					if fd.UnderGoType == "string" || fd.UnderGoType == common.ValuerType || fd.UnderGoType == common.JSONType {
 						col.fDefault = "DEFAULT '" + p.Value + "'"
 					} else {
 						col.fDefault = "DEFAULT " + p.Value
@@ -278,8 +308,16 @@ func (msf *MSSQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 				case "constraint":
 					if p.Value == "unique" {
 						col.fUniqueConstraint = "UNIQUE"
+					} else {
+						constraints = msf.processConstraintTag(constraints, tn, fd.FName, p.Value)
 					}
 
+				case "check":
+					constraints = msf.processCheckTag(constraints, tn, fd.FName, p.Value)
+
+				case "enum":
+					constraints = msf.processEnumTag(constraints, tn, fd.FName, p.Value)
+
 				case "index":
 					switch p.Value {
 					case "non-unique":
@@ -315,6 +353,12 @@ func (msf *MSSQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 			}
 		}
 		fldef[idx].FType = col.fType
+		if col.uType != "" {
+			fldef[idx].FType = col.uType
+		}
+
+		// retain the column definition for comparison in AlterTables
+		columns = append(columns, col)
 
 		// add the current column to the schema
 		if col.uType != "" {
@@ -337,6 +381,11 @@ func (msf *MSSQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 		tableSchema = tableSchema + ", "
 	}
 
+	// add the table-level unique and check constraints
+	for _, cn := range msf.constraintNames(constraints) {
+		tableSchema = tableSchema + msf.constraintClause(cn, constraints[cn]) + ", "
+	}
+
 	if tableSchema != "" && pKeys == "" {
 		tableSchema = strings.TrimSpace(tableSchema)
 		tableSchema = strings.TrimSuffix(tableSchema, ",")
@@ -354,6 +403,8 @@ func (msf *MSSQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 		flDef:     fldef,
 		seq:       sequences,
 		ind:       indexes,
+		cons:      constraints,
+		cols:      columns,
 		fkey:      fKeys,
 		pk:        pKeys,
 		err:       err,
@@ -369,24 +420,34 @@ func (msf *MSSQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 // by msf.DB.
 func (msf *MSSQLFlavor) CreateTables(i ...interface{}) error {
 
+	// views are created once the tables are in place
+	i, views := splitViews(i)
+
 	// call createTables specifying that the call has not originated
 	// from within the AlterTables(...) method.
 	_, err := msf.createTables(false, i)
 	if err != nil {
 		return err
 	}
-	return nil
+	return msf.createViews(msf, views...)
 }
 
 // DropTables drops tables on the db if they exist, based on
 // the provided list of go struct definitions.
 func (msf *MSSQLFlavor) DropTables(i ...interface{}) error {
 
+	// views are dropped ahead of the tables they select from
+	i, views := splitViews(i)
+	err := msf.dropViews(msf, views...)
+	if err != nil {
+		return err
+	}
+
 	dropSchema := ""
 	for t := range i {
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := msf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in msf.DropTables")
 		}
@@ -398,8 +459,9 @@ func (msf *MSSQLFlavor) DropTables(i ...interface{}) error {
 			if msf.log {
 				log.Printf("table %s exists - adding to drop schema...\n", tn)
 			}
-			dropSchema = dropSchema + "DROP TABLE " + tn + ";"
+			dropSchema = dropSchema + msf.dropTableSQL(tn)
 			msf.ProcessSchema(dropSchema)
+			msf.dropInPlan(tn)
 			dropSchema = ""
 		}
 	}
@@ -411,6 +473,7 @@ func (msf *MSSQLFlavor) DropTables(i ...interface{}) error {
 func (msf *MSSQLFlavor) AlterTables(i ...interface{}) error {
 
 	var err error
+	i, views := splitViews(i)
 	fkBuffer := make([]ForeignKeyBuffer, 0)
 	ci := make([]interface{}, 0)
 	ai := make([]interface{}, 0)
@@ -419,11 +482,21 @@ func (msf *MSSQLFlavor) AlterTables(i ...interface{}) error {
 	for t := range i {
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := msf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in msf.AlterTables")
 		}
 
+		// rename the table of a model tagged with renamed_from; the
+		// renamed table cannot be examined in plan mode.
+		renamed, err := msf.renameTable(msf, tn, i[t])
+		if err != nil {
+			return err
+		}
+		if renamed && msf.planning {
+			continue
+		}
+
 		// if the table does not exist, add the Model{} definition to
 		// the CreateTables buffer (ci).
 		// if the table does exist, add the Model{} definition to  the
@@ -449,7 +522,7 @@ func (msf *MSSQLFlavor) AlterTables(i ...interface{}) error {
 	for t, ent := range ai {
 
 		// determine the table name
-		tn := common.GetTableName(ai[t])
+		tn := msf.TableName(ai[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in msf.AlterTables")
 		}
@@ -465,15 +538,21 @@ func (msf *MSSQLFlavor) AlterTables(i ...interface{}) error {
 		// build the altered table schema and get its components
 		tc := msf.buildTablSchema(tn, ai[t])
 
+		// rename the columns of fields tagged with renamed_from
+		renamed, err := msf.renameColumns(msf, tn, tc)
+		if err != nil {
+			return err
+		}
+
 		// go through the latest version of the model and check each
 		// field against its definition in the database.
 		qt := msf.GetDBQuote()
-		alterSchema := "ALTER TABLE " + qt + tn + qt + " ADD "
+		alterSchema := "ALTER TABLE " + quotedTableName(tn, qt) + " ADD "
 		var cols []string
 
 		for _, fd := range tc.flDef {
 			// new columns first
-			if !msf.ExistsColumn(tn, fd.FName) && fd.NoDB == false {
+			if !msf.ExistsColumn(tn, fd.FName) && !renamed[fd.FName] && fd.NoDB == false {
 
 				colSchema := qt + fd.FName + qt + " " + fd.FType
 				for _, p := range fd.SqacPairs {
@@ -484,7 +563,7 @@ func (msf *MSSQLFlavor) AlterTables(i ...interface{}) error {
 
 					case "default":
 						switch fd.UnderGoType {
-						case "string":
+						case "string", common.ValuerType, common.JSONType:
 							colSchema = colSchema + " DEFAULT '" + p.Value + "'"
 
 						case "bool":
@@ -525,13 +604,46 @@ func (msf *MSSQLFlavor) AlterTables(i ...interface{}) error {
 			msf.ProcessSchema(alterSchema)
 		}
 
+		// bring the existing columns in line with the model
+		err = msf.alterColumns(tn, tc)
+		if err != nil {
+			return err
+		}
+
 		// add indexes if required
-		for k, v := range tc.ind {
+		for _, k := range indexNames(tc.ind) {
+			v := tc.ind[k]
 			if !msf.ExistsIndex(v.TableName, k) {
 				msf.CreateIndex(k, v)
 			}
 		}
 
+		// add unique and check constraints if required
+		for _, cn := range msf.constraintNames(tc.cons) {
+			if !msf.ExistsConstraint(tn, cn) {
+				err = msf.CreateConstraint(cn, tc.cons[cn])
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// re-create the check constraints whose expression has changed
+		changed, err := msf.changedChecks(msf, tn, tc.cons)
+		if err != nil {
+			return err
+		}
+		for _, cn := range changed {
+			err = msf.DropConstraint(tn, cn)
+			if err != nil {
+				return err
+			}
+			err = msf.CreateConstraint(cn, tc.cons[cn])
+			if err != nil {
+				return err
+			}
+		}
+
 		// add to the list of foreign-keys
 		for _, v := range tc.fkey {
 			fkb := ForeignKeyBuffer{
@@ -558,7 +670,62 @@ func (msf *MSSQLFlavor) AlterTables(i ...interface{}) error {
 			}
 		}
 	}
-	return nil
+	return msf.createViews(msf, views...)
+}
+
+// ForTenant returns a handle sharing the connection to the MSSQL database, whose
+// table names are routed to the tables of tenant via the tenant resolver.
+func (msf *MSSQLFlavor) ForTenant(tenant string) PublicDB {
+	t := *msf
+	t.tenant = tenant
+	return &t
+}
+
+// CreateSchema creates schema sn on the connected MSSQL database if it
+// does not exist.
+func (msf *MSSQLFlavor) CreateSchema(sn string) error {
+	return msf.createSchema(msf, sn)
+}
+
+// DropSchema drops schema sn on the connected MSSQL database if it
+// exists.  Schemas still holding objects are not dropped.
+func (msf *MSSQLFlavor) DropSchema(sn string) error {
+	return msf.dropSchema(msf, sn)
+}
+
+// ExistsSchema checks the connected MSSQL database for the presence of
+// schema sn.
+func (msf *MSSQLFlavor) ExistsSchema(sn string) bool {
+
+	n := 0
+	qs := "SELECT COUNT(*) FROM sys.schemas WHERE name = ?;"
+	msf.QsLog(qs, sn)
+	err := msf.db.QueryRow(qs, sn).Scan(&n)
+	if err != nil {
+		return false
+	}
+	return n > 0
+}
+
+// createSchemaSQL returns the statement used to create schema sn.
+func (msf *MSSQLFlavor) createSchemaSQL(sn string) (string, error) {
+	return "CREATE SCHEMA " + sn + ";", nil
+}
+
+// dropSchemaSQL returns the statement used to drop schema sn.
+func (msf *MSSQLFlavor) dropSchemaSQL(sn string) (string, error) {
+	return "DROP SCHEMA " + sn + ";", nil
+}
+
+// mssqlSchema splits table name tn into its schema and table name, with
+// the schema defaulting to dbo.
+func mssqlSchema(tn string) (string, string) {
+
+	sn, n := splitTableName(tn)
+	if sn == "" {
+		sn = "dbo"
+	}
+	return sn, n
 }
 
 // ExistsTable checks the currently connected database and
@@ -566,7 +733,8 @@ func (msf *MSSQLFlavor) AlterTables(i ...interface{}) error {
 func (msf *MSSQLFlavor) ExistsTable(tn string) bool {
 
 	n := 0
-	etQuery := "SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = 'dbo' AND TABLE_NAME = '" + tn + "';"
+	sn, tn := mssqlSchema(tn)
+	etQuery := "SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = '" + sn + "' AND TABLE_NAME = '" + tn + "';"
 	msf.QsLog(etQuery)
 
 	msf.db.QueryRow(etQuery).Scan(&n)
@@ -576,6 +744,28 @@ func (msf *MSSQLFlavor) ExistsTable(tn string) bool {
 	return false
 }
 
+// ExistsView checks the connected MSSQL database for the presence of view
+// vn.
+func (msf *MSSQLFlavor) ExistsView(vn string) bool {
+
+	n := 0
+	sn, bn := mssqlSchema(vn)
+	qs := "SELECT COUNT(*) FROM INFORMATION_SCHEMA.VIEWS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?;"
+	msf.QsLog(qs, sn, bn)
+	msf.db.QueryRow(qs, sn, bn).Scan(&n)
+	return n > 0
+}
+
+// DropView drops view vn on the connected MSSQL database if it exists.
+func (msf *MSSQLFlavor) DropView(vn string) error {
+	return msf.dropView(msf, vn)
+}
+
+// createViewSQL returns the statement creating or replacing view vn.
+func (msf *MSSQLFlavor) createViewSQL(vn, query string) []string {
+	return []string{"CREATE OR ALTER VIEW " + vn + " AS " + query + ";"}
+}
+
 // GetDBName returns the name of the currently connected db
 func (msf *MSSQLFlavor) GetDBName() (dbName string) {
 
@@ -605,17 +795,37 @@ func (msf *MSSQLFlavor) ExistsIndex(tn string, in string) bool {
 	return false
 }
 
+// ExistsConstraint checks the connected database for the presence of
+// the named unique or check constraint on table tn.
+func (msf *MSSQLFlavor) ExistsConstraint(tn string, cn string) bool {
+
+	n := 0
+	qs := "SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND CONSTRAINT_NAME = ?;"
+	sn, bn := mssqlSchema(tn)
+	msf.QsLog(qs, sn, bn, cn)
+	msf.db.QueryRow(qs, sn, bn, cn).Scan(&n)
+	if n > 0 {
+		return true
+	}
+	return false
+}
+
 // DropIndex drops the specfied index on the connected database.
 func (msf *MSSQLFlavor) DropIndex(tn string, in string) error {
 
 	if msf.ExistsIndex(tn, in) {
-		indexSchema := "DROP INDEX " + in + " ON " + tn + ";"
+		indexSchema := msf.dropIndexSQL(tn, in)
 		msf.ProcessSchema(indexSchema)
 		return nil
 	}
 	return nil
 }
 
+// dropIndexSQL returns the statement used to drop index in on table tn.
+func (msf *MSSQLFlavor) dropIndexSQL(tn string, in string) string {
+	return "DROP INDEX " + in + " ON " + tn + ";"
+}
+
 // ExistsColumn checks the currently connected database and
 // returns true if the named table-column is found to exist.
 // this checks the column name only, not the column data-type
@@ -624,9 +834,10 @@ func (msf *MSSQLFlavor) ExistsColumn(tn string, cn string) bool {
 
 	n := 0
 	if msf.ExistsTable(tn) {
-		qs := "SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS WHERE table_name = ? AND column_name = ?;"
-		msf.QsLog(qs, tn, cn)
-		msf.db.QueryRow(qs, tn, cn).Scan(&n)
+		qs := "SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS WHERE table_schema = ? AND table_name = ? AND column_name = ?;"
+		sn, bn := mssqlSchema(tn)
+		msf.QsLog(qs, sn, bn, cn)
+		msf.db.QueryRow(qs, sn, bn, cn).Scan(&n)
 		if n > 0 {
 			return true
 		}
@@ -634,6 +845,272 @@ func (msf *MSSQLFlavor) ExistsColumn(tn string, cn string) bool {
 	return false
 }
 
+// readColumns reads the column definitions of table tn from the
+// INFORMATION_SCHEMA of the connected MSSQL database.
+func (msf *MSSQLFlavor) readColumns(tn string) ([]ColumnInfo, error) {
+
+	qs := "SELECT c.COLUMN_NAME, c.DATA_TYPE, COALESCE(c.CHARACTER_MAXIMUM_LENGTH, 0), COALESCE(c.NUMERIC_PRECISION, 0), COALESCE(c.NUMERIC_SCALE, 0), " +
+		"c.IS_NULLABLE, COALESCE(c.COLUMN_DEFAULT, ''), " +
+		"(SELECT COUNT(*) FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE k INNER JOIN INFORMATION_SCHEMA.TABLE_CONSTRAINTS t " +
+		"ON k.CONSTRAINT_NAME = t.CONSTRAINT_NAME AND k.TABLE_SCHEMA = t.TABLE_SCHEMA WHERE t.CONSTRAINT_TYPE = 'PRIMARY KEY' " +
+		"AND k.TABLE_SCHEMA = c.TABLE_SCHEMA AND k.TABLE_NAME = c.TABLE_NAME AND k.COLUMN_NAME = c.COLUMN_NAME), " +
+		"COALESCE(COLUMNPROPERTY(OBJECT_ID(c.TABLE_SCHEMA + '.' + c.TABLE_NAME), c.COLUMN_NAME, 'IsIdentity'), 0) " +
+		"FROM INFORMATION_SCHEMA.COLUMNS c WHERE c.TABLE_SCHEMA = ? AND c.TABLE_NAME = ? ORDER BY c.ORDINAL_POSITION;"
+	sn, bn := mssqlSchema(tn)
+	msf.QsLog(qs, sn, bn)
+
+	rows, err := msf.db.Query(qs, sn, bn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols := make([]ColumnInfo, 0)
+	for rows.Next() {
+		var ci ColumnInfo
+		var length, precision, scale, pk, identity int
+		var nullable string
+		err = rows.Scan(&ci.Name, &ci.Type, &length, &precision, &scale, &nullable, &ci.Default, &pk, &identity)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(ci.Type) {
+		case "varchar", "nvarchar", "char", "nchar", "varbinary", "binary":
+			if length == -1 {
+				ci.Type = ci.Type + "(max)"
+			} else {
+				ci.Type = ci.Type + "(" + strconv.Itoa(length) + ")"
+			}
+		case "numeric", "decimal":
+			ci.Type = ci.Type + "(" + strconv.Itoa(precision) + "," + strconv.Itoa(scale) + ")"
+		}
+		ci.Nullable = nullable == "YES"
+		ci.PrimaryKey = pk > 0
+		ci.Identity = identity > 0
+		cols = append(cols, ci)
+	}
+	return msf.planColumns(tn, cols), rows.Err()
+}
+
+// normalizeColumn maps the MSSQL type aliases and default expression
+// formats onto a common representation for column comparison.  MSSQL
+// reports defaults wrapped in parentheses; ((0)) or ('YYC') for example.
+func (msf *MSSQLFlavor) normalizeColumn(ci ColumnInfo) ColumnInfo {
+
+	t := strings.ToLower(strings.Replace(strings.TrimSpace(ci.Type), " ", "", -1))
+	if t == "integer" {
+		t = "int"
+	}
+	ci.Type = t
+
+	d := trimDefault(ci.Default)
+	switch strings.ToLower(d) {
+	case "true":
+		d = "1"
+	case "false":
+		d = "0"
+	}
+	ci.Default = d
+	return ci
+}
+
+// dropDefaultSQL returns a statement that drops the default constraint
+// bound to column cn of table tn.  The system-generated name of the
+// constraint differs from db to db, so the name is looked up when the
+// statement is executed.
+func (msf *MSSQLFlavor) dropDefaultSQL(tn string, cn string) string {
+
+	return "DECLARE @dcn sysname SELECT @dcn = d.name FROM sys.default_constraints d INNER JOIN sys.columns c " +
+		"ON d.parent_object_id = c.object_id AND d.parent_column_id = c.column_id " +
+		"WHERE d.parent_object_id = OBJECT_ID('" + tn + "') AND c.name = '" + cn + "' " +
+		"IF @dcn IS NOT NULL EXEC('ALTER TABLE " + tn + " DROP CONSTRAINT ' + @dcn);"
+}
+
+// readTableNames reads the names of the tables in the schema set via
+// SetSchema, or the dbo schema, of the connected MSSQL database.
+func (msf *MSSQLFlavor) readTableNames() ([]string, error) {
+
+	qs := "SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE' ORDER BY TABLE_NAME;"
+	sn := msf.schema
+	if sn == "" {
+		sn = "dbo"
+	}
+	msf.QsLog(qs, sn)
+
+	tns := make([]string, 0)
+	err := msf.db.Select(&tns, qs, sn)
+	if err != nil {
+		return nil, err
+	}
+	return tns, nil
+}
+
+// readIndexes reads the indexes of table tn from the catalog views of the
+// connected MSSQL database.  The primary-key index and the indexes backing
+// unique constraints are not included.
+func (msf *MSSQLFlavor) readIndexes(tn string) (map[string]IndexInfo, error) {
+
+	qs := "SELECT i.name, i.is_unique, c.name FROM sys.indexes i " +
+		"INNER JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id " +
+		"INNER JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id " +
+		"WHERE i.object_id = OBJECT_ID(?) AND i.is_primary_key = 0 AND i.is_unique_constraint = 0 " +
+		"ORDER BY i.name, ic.key_ordinal;"
+	msf.QsLog(qs, tn)
+
+	rows, err := msf.db.Query(qs, tn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ind := make(map[string]IndexInfo)
+	for rows.Next() {
+		var in, cn string
+		var unique bool
+		err = rows.Scan(&in, &unique, &cn)
+		if err != nil {
+			return nil, err
+		}
+		addIndexField(ind, tn, in, unique, cn)
+	}
+	return ind, rows.Err()
+}
+
+// readForeignKeys reads the foreign-keys of table tn from the catalog
+// views of the connected MSSQL database.
+func (msf *MSSQLFlavor) readForeignKeys(tn string) ([]FKeyInfo, error) {
+
+	qs := "SELECT f.name, fc.name, OBJECT_NAME(k.referenced_object_id), rc.name FROM sys.foreign_keys f " +
+		"INNER JOIN sys.foreign_key_columns k ON k.constraint_object_id = f.object_id " +
+		"INNER JOIN sys.columns fc ON fc.object_id = k.parent_object_id AND fc.column_id = k.parent_column_id " +
+		"INNER JOIN sys.columns rc ON rc.object_id = k.referenced_object_id AND rc.column_id = k.referenced_column_id " +
+		"WHERE f.parent_object_id = OBJECT_ID(?) ORDER BY f.name, k.constraint_column_id;"
+	msf.QsLog(qs, tn)
+
+	rows, err := msf.db.Query(qs, tn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fks := make([]FKeyInfo, 0)
+	for rows.Next() {
+		fk := FKeyInfo{FromTable: tn}
+		err = rows.Scan(&fk.FKeyName, &fk.FromField, &fk.RefTable, &fk.RefField)
+		if err != nil {
+			return nil, err
+		}
+		fks = append(fks, fk)
+	}
+	return fks, rows.Err()
+}
+
+// readChecks reads the names and definitions of the check constraints
+// of table tn from the catalog views of the connected MSSQL database.
+func (msf *MSSQLFlavor) readChecks(tn string) (map[string]string, error) {
+
+	qs := "SELECT cc.name, cc.definition FROM sys.check_constraints cc " +
+		"INNER JOIN sys.tables t ON t.object_id = cc.parent_object_id INNER JOIN sys.schemas s ON s.schema_id = t.schema_id " +
+		"WHERE s.name = ? AND t.name = ?;"
+	sn, bn := mssqlSchema(tn)
+	msf.QsLog(qs, sn, bn)
+
+	rows, err := msf.db.Query(qs, sn, bn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := make(map[string]string)
+	for rows.Next() {
+		var cn, def string
+		err = rows.Scan(&cn, &def)
+		if err != nil {
+			return nil, err
+		}
+		checks[cn] = def
+	}
+	return checks, rows.Err()
+}
+
+// renameColumnSQL returns the statement renaming column from of table tn
+// to column to.
+func (msf *MSSQLFlavor) renameColumnSQL(tn, from, to string) string {
+	return "EXEC sp_rename '" + tn + "." + from + "', '" + to + "', 'COLUMN';"
+}
+
+// renameTableSQL returns the statements renaming table from to table to.
+func (msf *MSSQLFlavor) renameTableSQL(from, to string) ([]string, error) {
+	return []string{"EXEC sp_rename '" + from + "', '" + bareTableName(to) + "';"}, nil
+}
+
+// alterColumns brings the existing columns of table tn in line with the
+// model captured in tc.  MSSQL binds column defaults through named
+// constraints, so a changed default is dropped and re-added.  Columns
+// that are no longer part of the model are dealt with according to the
+// destructive-change policy.
+func (msf *MSSQLFlavor) alterColumns(tn string, tc TblComponents) error {
+
+	dbCols, err := msf.readColumns(tn)
+	if err != nil {
+		return err
+	}
+
+	deltas := msf.diffColumns(msf.modelColumns(tc), dbCols, msf.normalizeColumn)
+	deltas, err = msf.applyDestructivePolicy(tn, deltas)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range msf.alterColumnSQL(tn, deltas) {
+		_, err = msf.Exec(s)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// alterColumnSQL returns the statements that apply the column deltas
+// to table tn.
+func (msf *MSSQLFlavor) alterColumnSQL(tn string, deltas []colDelta) []string {
+
+	qt := msf.GetDBQuote()
+	alterSchema := "ALTER TABLE " + quotedTableName(tn, qt)
+	var stmts []string
+	for _, d := range deltas {
+
+		// a bound default prevents both the altering and the dropping of a column
+		bound := d.from.Default != "" && (d.op == "DROP" || d.typeChg || d.nullChg || d.dfltChg)
+		if bound {
+			stmts = append(stmts, msf.dropDefaultSQL(tn, d.name))
+		}
+
+		switch d.op {
+		case "DROP":
+			stmts = append(stmts, alterSchema+" DROP COLUMN "+qt+d.name+qt+";")
+
+		case "ALTER":
+			if d.typeChg || d.nullChg {
+				colSchema := " ALTER COLUMN " + qt + d.name + qt + " " + d.to.Type
+				if d.to.Nullable {
+					colSchema = colSchema + " NULL"
+				} else {
+					if d.nullChg && d.to.Default != "" {
+						stmts = append(stmts, "UPDATE "+quotedTableName(tn, qt)+" SET "+qt+d.name+qt+" = "+d.to.Default+" WHERE "+qt+d.name+qt+" IS NULL;")
+					}
+					colSchema = colSchema + " NOT NULL"
+				}
+				stmts = append(stmts, alterSchema+colSchema+";")
+			}
+			if d.to.Default != "" && (bound || d.dfltChg) {
+				stmts = append(stmts, alterSchema+" ADD CONSTRAINT df_"+bareTableName(tn)+"_"+d.name+" DEFAULT "+d.to.Default+" FOR "+qt+d.name+qt+";")
+			}
+		}
+	}
+	return stmts
+}
+
 // DestructiveResetTables drops tables on the MSSQL db if they exist,
 // as well as any related objects such as sequences.  this is
 // useful if you wish to regenerated your table and the
@@ -651,6 +1128,77 @@ func (msf *MSSQLFlavor) DestructiveResetTables(i ...interface{}) error {
 	return nil
 }
 
+// PlanCreateTables returns the ordered list of statements that CreateTables
+// would execute on the connected MSSQL db, without making any changes.
+func (msf *MSSQLFlavor) PlanCreateTables(i ...interface{}) ([]string, error) {
+	return msf.runPlan(func() error { return msf.CreateTables(i...) })
+}
+
+// PlanAlterTables returns the ordered list of statements that AlterTables
+// would execute on the connected MSSQL db, without making any changes.
+func (msf *MSSQLFlavor) PlanAlterTables(i ...interface{}) ([]string, error) {
+	return msf.runPlan(func() error { return msf.AlterTables(i...) })
+}
+
+// PlanDropTables returns the ordered list of statements that DropTables
+// would execute on the connected MSSQL db, without making any changes.
+func (msf *MSSQLFlavor) PlanDropTables(i ...interface{}) ([]string, error) {
+	return msf.runPlan(func() error { return msf.DropTables(i...) })
+}
+
+// PlanDestructiveResetTables returns the ordered list of statements that
+// DestructiveResetTables would execute on the connected MSSQL db, without
+// making any changes.
+func (msf *MSSQLFlavor) PlanDestructiveResetTables(i ...interface{}) ([]string, error) {
+	return msf.runPlan(func() error { return msf.DestructiveResetTables(i...) })
+}
+
+// SnapshotTables returns a snapshot of the MSSQL tables described by
+// the models.
+func (msf *MSSQLFlavor) SnapshotTables(i ...interface{}) (Snapshot, error) {
+	return msf.snapshotTables(msf, i...)
+}
+
+// PlanMigration returns the statements required to migrate the MSSQL
+// tables from their state in the connected db, or in snapshot from, to
+// the models, along with the statements required to revert the migration.
+func (msf *MSSQLFlavor) PlanMigration(from *Snapshot, i ...interface{}) ([]string, []string, error) {
+	return msf.planMigration(msf, from, i...)
+}
+
+// GenerateModels reads the named MSSQL tables, or all tables if no names
+// are provided, and returns the source of a go file in package pkg holding
+// a sqac-tagged model for each of them.
+func (msf *MSSQLFlavor) GenerateModels(pkg string, tn ...string) ([]byte, error) {
+	return msf.generateModels(msf, pkg, tn...)
+}
+
+// DescribeTable reads the columns, primary-key, indexes, foreign-keys and
+// sequences of MSSQL table tn.
+func (msf *MSSQLFlavor) DescribeTable(tn string) (TableInfo, error) {
+	return msf.describeTable(msf, tn)
+}
+
+// tableComponents returns the components of the MSSQL table described
+// by model ent.
+func (msf *MSSQLFlavor) tableComponents(tn string, ent interface{}) TblComponents {
+	return msf.buildTablSchema(tn, ent)
+}
+
+// addColumnSQL returns the statement used to add column ci to table tn.
+func (msf *MSSQLFlavor) addColumnSQL(tn string, ci ColumnInfo) string {
+
+	qt := msf.GetDBQuote()
+	colSchema := "ALTER TABLE " + quotedTableName(tn, qt) + " ADD " + qt + ci.Name + qt + " " + ci.Type
+	if ci.Default != "" {
+		colSchema = colSchema + " DEFAULT " + ci.Default
+	}
+	if !ci.Nullable {
+		colSchema = colSchema + " NOT NULL"
+	}
+	return colSchema + ";"
+}
+
 // AlterSequenceStart may be used to make changes to the start value of the
 // named identity-field on the currently connected MSSQL database.
 func (msf *MSSQLFlavor) AlterSequenceStart(name string, start int) error {
@@ -686,7 +1234,11 @@ func (msf *MSSQLFlavor) GetNextSequenceValue(name string) (int, error) {
 func (msf *MSSQLFlavor) ExistsForeignKeyByName(i interface{}, fkn string) (bool, error) {
 
 	var count uint64
-	fkQuery := "SELECT COUNT(*) FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS WHERE CONSTRAINT_NAME = '" + fkn + "';"
+	sn := "dbo"
+	if i != nil {
+		sn, _ = mssqlSchema(msf.TableName(i))
+	}
+	fkQuery := "SELECT COUNT(*) FROM INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS WHERE CONSTRAINT_SCHEMA = '" + sn + "' AND CONSTRAINT_NAME = '" + fkn + "';"
 	msf.QsLog(fkQuery)
 
 	err := msf.Get(&count, fkQuery)
@@ -711,6 +1263,42 @@ func (msf *MSSQLFlavor) ExistsForeignKeyByFields(i interface{}, ft, rt, ff, rf s
 	return msf.ExistsForeignKeyByName(i, fkn)
 }
 
+// CallProcedure executes MSSQL stored procedure name, passing the fields of
+// in as named parameters (@field).  If out points to a struct, its fields
+// are passed as named OUTPUT parameters and receive the values returned
+// by the procedure.  If out points to a slice of structs, the result set
+// of the procedure is read into it.
+func (msf *MSSQLFlavor) CallProcedure(name string, in interface{}, out interface{}) error {
+
+	ps, err := procParams(in)
+	if err != nil {
+		return err
+	}
+	outs, err := procOutParams(out)
+	if err != nil {
+		return err
+	}
+
+	params := make([]string, 0)
+	args := make([]interface{}, 0)
+	for _, p := range ps {
+		params = append(params, "@"+p.name+" = @"+p.name)
+		args = append(args, sql.Named(p.name, p.value.Interface()))
+	}
+	for _, p := range outs {
+		params = append(params, "@"+p.name+" = @"+p.name+" OUTPUT")
+		args = append(args, sql.Named(p.name, p.outArg()))
+	}
+	qs := strings.TrimSpace("EXEC "+name+" "+strings.Join(params, ", ")) + ";"
+	msf.QsLog(qs, args...)
+
+	if isResultSet(out) {
+		return msf.db.Select(out, qs, args...)
+	}
+	_, err = msf.db.Exec(qs, args...)
+	return err
+}
+
 //================================================================
 // CRUD ops
 //================================================================
@@ -731,7 +1319,8 @@ func (msf *MSSQLFlavor) Create(ent interface{}) error {
 	// build the mssql insert query
 	insFlds := "("
 	insVals := "("
-	for k, v := range info.fldMap {
+	for _, k := range info.fields() {
+		v := info.fldMap[k]
 		if v == "DEFAULT" {
 			continue
 		}
@@ -743,30 +1332,34 @@ func (msf *MSSQLFlavor) Create(ent interface{}) error {
 
 	// build the mssql insert query
 	insQuery := "INSERT INTO " + info.tn + " " + insFlds + " " + "VALUES " + insVals + ";"
-	msf.QsLog(insQuery)
+	msf.QsLog(insQuery, info.args...)
 
 	// clear the source data - deals with non-persistent columns
 	e := reflect.ValueOf(info.ent).Elem()
 	e.Set(reflect.Zero(e.Type()))
 
 	// attempt the insert and read the result back into info.resultMap
-	result, err := msf.db.Exec(insQuery)
+	result, err := msf.db.Exec(insQuery, info.args...)
 	if err != nil {
 		return err
 	}
 
-	lastID, err := result.LastInsertId()
-	if err != nil {
-		return err
+	var lastID int64
+	if info.incKeyName != "" {
+		lastID, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
 	}
 
 	// "SELECT * FROM %s WHERE %s = %v;", info.tn, info.incKeyName, lastID
-	selQuery := "SELECT * FROM " + info.tn + " WHERE " + info.incKeyName + " = " + strconv.FormatInt(lastID, 10) + ";"
+	selQuery := "SELECT * FROM " + info.tn + " WHERE " + info.keyCondition(lastID) + ";"
 	msf.QsLog(selQuery)
-	err = msf.db.QueryRowx(selQuery).StructScan(info.ent) //.MapScan(info.resultMap) // SliceScan
+	err = msf.structScan(msf.db.QueryRowx(selQuery), info.ent) //.MapScan(info.resultMap) // SliceScan
 	if err != nil {
 		return err
 	}
+	msf.readTimes(info.ent)
 	info.entValue = reflect.ValueOf(info.ent)
 	return nil
 }
@@ -802,21 +1395,22 @@ func (msf *MSSQLFlavor) Update(ent interface{}) error {
 	keyList = strings.TrimSuffix(keyList, " AND")
 
 	colList := ""
-	for k, v := range info.fldMap {
+	for _, k := range info.fields() {
+		v := info.fldMap[k]
 		colList = fmt.Sprintf("%s %s = %s, ", colList, k, v)
 	}
 	colList = strings.TrimSuffix(colList, ", ")
 
 	// "UPDATE %s SET %s WHERE %s;", info.tn, colList, keyList
 	updQuery := "UPDATE " + info.tn + " SET " + colList + " WHERE " + keyList + ";"
-	msf.QsLog(updQuery)
+	msf.QsLog(updQuery, info.args...)
 
 	// clear the source data - deals with non-persistet columns
 	e := reflect.ValueOf(info.ent).Elem()
 	e.Set(reflect.Zero(e.Type()))
 
 	// attempt the update and check for errors
-	_, err = msf.db.Exec(updQuery)
+	_, err = msf.db.Exec(updQuery, info.args...)
 	if err != nil {
 		return err
 	}
@@ -824,10 +1418,11 @@ func (msf *MSSQLFlavor) Update(ent interface{}) error {
 	// read the updated row
 	selQuery := "SELECT * FROM " + info.tn + " WHERE " + keyList + ";"
 	msf.QsLog(selQuery)
-	err = msf.db.QueryRowx(selQuery).StructScan(info.ent) // .MapScan(info.resultMap) // SliceScan
+	err = msf.structScan(msf.db.QueryRowx(selQuery), info.ent) // .MapScan(info.resultMap) // SliceScan
 	if err != nil {
 		return err
 	}
+	msf.readTimes(info.ent)
 	info.entValue = reflect.ValueOf(info.ent)
 	return nil
 }
@@ -849,15 +1444,19 @@ func (msf *MSSQLFlavor) GetEntitiesWithCommands(ents interface{}, pList []GetPar
 	testVar := reflect.New(entTypeElem)
 
 	// determine the db table name
-	tn := common.GetTableName(ents)
+	tn := msf.TableName(ents)
 
 	// are there any parameters to include in the query?
 	var pv []interface{}
 	if pList != nil && len(pList) > 0 {
 		paramString = " WHERE"
 		for i := range pList {
-			paramString = paramString + " " + common.CamelToSnake(pList[i].FieldName) + " " + pList[i].Operand + " ? " + pList[i].NextOperator
-			pv = append(pv, pList[i].ParamValue)
+			pred, args, err := msf.paramPredicate(pList[i])
+			if err != nil {
+				return nil, err
+			}
+			paramString = paramString + " " + pred + " " + pList[i].NextOperator
+			pv = append(pv, args...)
 		}
 	}
 	if msf.log {
@@ -971,11 +1570,12 @@ func (msf *MSSQLFlavor) GetEntitiesWithCommands(ents interface{}, pList []GetPar
 	// into the ents interface (slice)
 	entsv := reflect.ValueOf(ents)
 	for rows.Next() {
-		err = rows.StructScan(testVar.Interface())
+		err = msf.structScan(rows, testVar.Interface())
 		if err != nil {
 			log.Println("scan error:", err)
 			return nil, err
 		}
+		msf.readTimes(testVar.Interface())
 		entsv = reflect.Append(entsv, testVar.Elem())
 	}
 	// ents = entsv.Interface()
@@ -1000,15 +1600,19 @@ func (msf *MSSQLFlavor) GetEntitiesCP(ents interface{}, pList []GetParam, cmdMap
 	dstRow := reflect.New(t)
 
 	// determine the db table name
-	tn := common.GetTableName(ents)
+	tn := msf.TableName(ents)
 
 	// are there any parameters to include in the query?
 	var pv []interface{}
 	if pList != nil && len(pList) > 0 {
 		paramString = " WHERE"
 		for i := range pList {
-			paramString = paramString + " " + common.CamelToSnake(pList[i].FieldName) + " " + pList[i].Operand + " ? " + pList[i].NextOperator
-			pv = append(pv, pList[i].ParamValue)
+			pred, args, err := msf.paramPredicate(pList[i])
+			if err != nil {
+				return 0, err
+			}
+			paramString = paramString + " " + pred + " " + pList[i].NextOperator
+			pv = append(pv, args...)
 		}
 	}
 
@@ -1134,11 +1738,12 @@ func (msf *MSSQLFlavor) GetEntitiesCP(ents interface{}, pList []GetParam, cmdMap
 	var c uint64
 	slice := reflect.MakeSlice(sliceTypeElem, 0, 0)
 	for rows.Next() {
-		err = rows.StructScan(dstRow.Interface())
+		err = msf.structScan(rows, dstRow.Interface())
 		if err != nil {
 			log.Println("GetEntitiesCP scan error:", err)
 			return 0, err
 		}
+		msf.readTimes(dstRow.Interface())
 		slice = reflect.Append(slice, dstRow.Elem())
 		results.Set(reflect.Append(results, dstRow.Elem()))
 		c++
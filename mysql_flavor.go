@@ -1,9 +1,12 @@
 package sqac
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -50,6 +53,13 @@ func (myf *MySQLFlavor) createTables(calledFromAlter bool, i ...interface{}) ([]
 
 	// get the list of table Model{}s
 	di := i[0].([]interface{})
+
+	// create the schemas of the tables if need be
+	err := myf.createTableSchemas(myf, di...)
+	if err != nil {
+		return nil, err
+	}
+
 	for t, ent := range di {
 
 		ftr := reflect.TypeOf(ent)
@@ -58,14 +68,15 @@ func (myf *MySQLFlavor) createTables(calledFromAlter bool, i ...interface{}) ([]
 		}
 
 		// determine the table name
-		tn := common.GetTableName(di[t])
+		tn := myf.TableName(di[t])
 		if tn == "" {
 			return nil, fmt.Errorf("unable to determine table name in myf.createTables")
 		}
 
 		// if the table is found to exist, skip the creation
-		// and move on to the next table in the list.
-		if myf.ExistsTable(tn) {
+		// and move on to the next table in the list.  tables
+		// dropped earlier in a plan are considered absent.
+		if !myf.absentInPlan(tn) && myf.ExistsTable(tn) {
 			if myf.log {
 				log.Printf("createTable - table %s exists - skipping...\n", tn)
 			}
@@ -77,15 +88,15 @@ func (myf *MySQLFlavor) createTables(calledFromAlter bool, i ...interface{}) ([]
 		myf.QsLog(tc.tblSchema)
 
 		// create the table on the db
-		myf.db.MustExec(tc.tblSchema)
+		myf.mustExec(tc.tblSchema)
 		for _, sq := range tc.seq {
 			start, _ := strconv.Atoi(sq.Value)
 			myf.AlterSequenceStart(sq.Name, start)
 		}
 
 		// create the table indices
-		for k, in := range tc.ind {
-			myf.CreateIndex(k, in)
+		for _, k := range indexNames(tc.ind) {
+			myf.CreateIndex(k, tc.ind[k])
 		}
 
 		// add foreign-key information to the buffer
@@ -132,8 +143,10 @@ func (myf *MySQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 	pKeys := ""
 	var sequences []common.SqacPair
 	indexes := make(map[string]IndexInfo)
+	constraints := make(map[string]ConstraintInfo)
+	columns := make([]ColComponents, 0)
 	fKeys := make([]FKeyInfo, 0)
-	tableSchema := "CREATE TABLE " + qt + tn + qt + "("
+	tableSchema := "CREATE TABLE " + quotedTableName(tn, qt) + "("
 
 	// get a list of the field names, go-types and db attributes.
 	// TagReader is a common function across db-flavors. For
@@ -195,15 +208,40 @@ func (myf *MySQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 
 		case "string":
 			col.fType = "varchar(255)" //
+			if fd.TagValue("primary_key") == "uuid" {
+				col.fType = "char(36)"
+			}
+			if ev := fd.TagValue("enum"); ev != "" {
+				col.fType = "enum(" + enumList(ev) + ")"
+			}
+
+		case common.DecimalType:
+			col.fType = decimalType("decimal", fd)
+
+		case common.JSONType, common.ArrayType:
+			col.fType = "json"
+
+		case common.ValuerType:
+			col.fType = "varchar(255)"
+
+		case common.DateType:
+			col.fType = "date"
+
+		case common.TimeOfDayType:
+			col.fType = "time"
 
 		case "time.Time":
 			col.fType = "timestamp"
 
+		case "[]byte":
+			col.fType = "longblob"
+
 		default:
 			err := fmt.Errorf("go type %s is not presently supported", fldef[idx].FType)
 			panic(err)
 		}
 		fldef[idx].FType = col.fType
+		col.uType = userType(fd, "varchar")
 
 		// read sqac tag pairs and apply
 		seqName := ""
@@ -218,11 +256,6 @@ func (myf *MySQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 					pKeys = pKeys + " " + qt + fd.FName + qt + ","
 
 					if p.Value == "inc" {
-						// warn that user-specified db_type type will be ignored
-						if col.uType != "" {
-							log.Printf("WARNING: %s auto-incrementing primary-key field %s has user-specified db_type: %s  user-type is ignored. \n", common.GetTableName(ent), col.fName, col.uType)
-							col.uType = ""
-						}
 						col.fAutoInc = true
 					}
 
@@ -237,7 +270,7 @@ func (myf *MySQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 					}
 
 				case "default":
-					if fd.UnderGoType == "string" {
+					if fd.UnderGoType == "string" || fd.UnderGoType == common.ValuerType || fd.UnderGoType == common.JSONType {
 						col.fDefault = "DEFAULT '" + p.Value + "'"
 					} else {
 						col.fDefault = "DEFAULT " + p.Value
@@ -250,8 +283,13 @@ func (myf *MySQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 				case "constraint":
 					if p.Value == "unique" {
 						col.fUniqueConstraint = "UNIQUE"
+					} else {
+						constraints = myf.processConstraintTag(constraints, tn, fd.FName, p.Value)
 					}
 
+				case "check":
+					constraints = myf.processCheckTag(constraints, tn, fd.FName, p.Value)
+
 				case "nullable":
 					if p.Value == "false" {
 						col.fNullable = "NOT NULL"
@@ -291,6 +329,12 @@ func (myf *MySQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 			}
 		}
 		fldef[idx].FType = col.fType
+		if col.uType != "" {
+			fldef[idx].FType = col.uType
+		}
+
+		// retain the column definition for comparison in AlterTables
+		columns = append(columns, col)
 
 		// add the current column to the schema
 		if col.uType != "" {
@@ -313,6 +357,11 @@ func (myf *MySQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 		tableSchema = tableSchema + ", "
 	}
 
+	// add the table-level unique and check constraints
+	for _, cn := range myf.constraintNames(constraints) {
+		tableSchema = tableSchema + myf.constraintClause(cn, constraints[cn]) + ", "
+	}
+
 	if tableSchema != "" && pKeys == "" {
 		tableSchema = strings.TrimSpace(tableSchema)
 		tableSchema = strings.TrimSuffix(tableSchema, ",")
@@ -330,6 +379,8 @@ func (myf *MySQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 		flDef:     fldef,
 		seq:       sequences,
 		ind:       indexes,
+		cons:      constraints,
+		cols:      columns,
 		fkey:      fKeys,
 		pk:        pKeys,
 		err:       err,
@@ -345,13 +396,16 @@ func (myf *MySQLFlavor) buildTablSchema(tn string, ent interface{}) TblComponent
 // by myf.DB.
 func (myf *MySQLFlavor) CreateTables(i ...interface{}) error {
 
+	// views are created once the tables are in place
+	i, views := splitViews(i)
+
 	// call createTables specifying that the call has not originated
 	// from within the AlterTables(...) method.
 	_, err := myf.createTables(false, i)
 	if err != nil {
 		return err
 	}
-	return nil
+	return myf.createViews(myf, views...)
 }
 
 // AlterTables alters tables on the MySQL database referenced
@@ -359,6 +413,7 @@ func (myf *MySQLFlavor) CreateTables(i ...interface{}) error {
 func (myf *MySQLFlavor) AlterTables(i ...interface{}) error {
 
 	var err error
+	i, views := splitViews(i)
 	fkBuffer := make([]ForeignKeyBuffer, 0)
 	ci := make([]interface{}, 0)
 	ai := make([]interface{}, 0)
@@ -367,11 +422,21 @@ func (myf *MySQLFlavor) AlterTables(i ...interface{}) error {
 	for t := range i {
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := myf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in pf.AlterTables")
 		}
 
+		// rename the table of a model tagged with renamed_from; the
+		// renamed table cannot be examined in plan mode.
+		renamed, err := myf.renameTable(myf, tn, i[t])
+		if err != nil {
+			return err
+		}
+		if renamed && myf.planning {
+			continue
+		}
+
 		// if the table does not exist, add the Model{} definition to
 		// the CreateTables buffer (ci).
 		// if the table does exist, add the Model{} definition to  the
@@ -397,7 +462,7 @@ func (myf *MySQLFlavor) AlterTables(i ...interface{}) error {
 	for t, ent := range ai {
 
 		// determine the table name
-		tn := common.GetTableName(ai[t])
+		tn := myf.TableName(ai[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in myf.AlterTables")
 		}
@@ -405,15 +470,21 @@ func (myf *MySQLFlavor) AlterTables(i ...interface{}) error {
 		// build the alter-table schema and get its components
 		tc := myf.buildTablSchema(tn, ai[t])
 
+		// rename the columns of fields tagged with renamed_from
+		renamed, err := myf.renameColumns(myf, tn, tc)
+		if err != nil {
+			return err
+		}
+
 		// go through the latest version of the model and check each
 		// field against its definition in the database.
 		qt := myf.GetDBQuote()
-		alterSchema := "ALTER TABLE " + qt + tn + qt
+		alterSchema := "ALTER TABLE " + quotedTableName(tn, qt)
 		var cols []string
 
 		for _, fd := range tc.flDef {
 			// new columns first
-			if !myf.ExistsColumn(tn, fd.FName) && fd.NoDB == false {
+			if !myf.ExistsColumn(tn, fd.FName) && !renamed[fd.FName] && fd.NoDB == false {
 
 				colSchema := "ADD COLUMN " + qt + fd.FName + qt + " " + fd.FType
 				for _, p := range fd.SqacPairs {
@@ -423,7 +494,7 @@ func (myf *MySQLFlavor) AlterTables(i ...interface{}) error {
 						panic(fmt.Errorf("aborting - cannot add a primary-key (table-field %s-%s) through migration", tn, fd.FName))
 
 					case "default":
-						if fd.UnderGoType == "string" {
+						if fd.UnderGoType == "string" || fd.UnderGoType == common.ValuerType || fd.UnderGoType == common.JSONType {
 							colSchema = colSchema + " DEFAULT '" + p.Value + "'"
 						} else {
 							colSchema = colSchema + " DEFAULT " + p.Value
@@ -452,13 +523,46 @@ func (myf *MySQLFlavor) AlterTables(i ...interface{}) error {
 			myf.ProcessSchema(alterSchema)
 		}
 
+		// bring the existing columns in line with the model
+		err = myf.alterColumns(tn, tc)
+		if err != nil {
+			return err
+		}
+
 		// add indexes if required
-		for k, v := range tc.ind {
+		for _, k := range indexNames(tc.ind) {
+			v := tc.ind[k]
 			if !myf.ExistsIndex(v.TableName, k) {
 				myf.CreateIndex(k, v)
 			}
 		}
 
+		// add unique and check constraints if required
+		for _, cn := range myf.constraintNames(tc.cons) {
+			if !myf.ExistsConstraint(tn, cn) {
+				err = myf.CreateConstraint(cn, tc.cons[cn])
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// re-create the check constraints whose expression has changed
+		changed, err := myf.changedChecks(myf, tn, tc.cons)
+		if err != nil {
+			return err
+		}
+		for _, cn := range changed {
+			err = myf.DropConstraint(tn, cn)
+			if err != nil {
+				return err
+			}
+			err = myf.CreateConstraint(cn, tc.cons[cn])
+			if err != nil {
+				return err
+			}
+		}
+
 		// add to the list of foreign-keys
 		for _, v := range tc.fkey {
 			fkb := ForeignKeyBuffer{
@@ -486,20 +590,322 @@ func (myf *MySQLFlavor) AlterTables(i ...interface{}) error {
 			}
 		}
 	}
+	return myf.createViews(myf, views...)
+}
+
+// ForTenant returns a handle sharing the connection to the MySQL server, whose
+// table names are routed to the tables of tenant via the tenant resolver.
+func (myf *MySQLFlavor) ForTenant(tenant string) PublicDB {
+	t := *myf
+	t.tenant = tenant
+	return &t
+}
+
+// CreateSchema creates schema (database) sn on the connected MySQL server
+// if it does not exist.
+func (myf *MySQLFlavor) CreateSchema(sn string) error {
+	return myf.createSchema(myf, sn)
+}
+
+// DropSchema drops schema (database) sn on the connected MySQL server if
+// it exists.  Schemas still holding tables are not dropped.
+func (myf *MySQLFlavor) DropSchema(sn string) error {
+	return myf.dropSchema(myf, sn)
+}
+
+// ExistsSchema checks the connected MySQL server for the presence of
+// schema (database) sn.
+func (myf *MySQLFlavor) ExistsSchema(sn string) bool {
+
+	n := 0
+	qs := "SELECT COUNT(*) FROM information_schema.SCHEMATA WHERE schema_name = ?;"
+	myf.QsLog(qs, sn)
+	err := myf.db.QueryRow(qs, sn).Scan(&n)
+	if err != nil {
+		return false
+	}
+	return n > 0
+}
+
+// createSchemaSQL returns the statement used to create schema sn.
+func (myf *MySQLFlavor) createSchemaSQL(sn string) (string, error) {
+	return "CREATE SCHEMA IF NOT EXISTS " + sn + ";", nil
+}
+
+// dropSchemaSQL returns the statement used to drop schema sn.  MySQL
+// drops the tables of a schema along with it, so schemas holding tables
+// are refused.
+func (myf *MySQLFlavor) dropSchemaSQL(sn string) (string, error) {
+
+	n := 0
+	qs := "SELECT COUNT(*) FROM information_schema.TABLES WHERE table_schema = ?;"
+	myf.QsLog(qs, sn)
+	err := myf.db.QueryRow(qs, sn).Scan(&n)
+	if err != nil {
+		return "", err
+	}
+	if n > 0 {
+		return "", fmt.Errorf("schema %s holds %d tables and cannot be dropped", sn, n)
+	}
+	return "DROP SCHEMA IF EXISTS " + sn + ";", nil
+}
+
+// readColumns reads the column definitions of table tn from the
+// information_schema of the connected MySQL database.
+func (myf *MySQLFlavor) readColumns(tn string) ([]ColumnInfo, error) {
+
+	qs := "SELECT column_name, column_type, is_nullable, column_default, column_key, extra FROM information_schema.COLUMNS WHERE table_schema = ? AND table_name = ? ORDER BY ordinal_position;"
+	dbName, bn := myf.splitSchema(tn)
+	myf.QsLog(qs, dbName, bn)
+
+	rows, err := myf.db.Query(qs, dbName, bn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols := make([]ColumnInfo, 0)
+	for rows.Next() {
+		var ci ColumnInfo
+		var nullable, key, extra string
+		var dflt sql.NullString
+		err = rows.Scan(&ci.Name, &ci.Type, &nullable, &dflt, &key, &extra)
+		if err != nil {
+			return nil, err
+		}
+		ci.Nullable = nullable == "YES"
+		ci.Default = dflt.String
+		ci.PrimaryKey = key == "PRI"
+		ci.Identity = strings.Contains(strings.ToLower(extra), "auto_increment")
+		cols = append(cols, ci)
+	}
+	return myf.planColumns(tn, cols), rows.Err()
+}
+
+// myIntWidthRegexp matches the display-width of MySQL integer types
+var myIntWidthRegexp = regexp.MustCompile(`^(tinyint|smallint|mediumint|int|bigint)\([0-9]+\)`)
+
+// normalizeColumn maps the MySQL type aliases and default expression
+// formats onto a common representation for column comparison.
+func (myf *MySQLFlavor) normalizeColumn(ci ColumnInfo) ColumnInfo {
+
+	t := strings.ToLower(strings.TrimSpace(ci.Type))
+	t = myIntWidthRegexp.ReplaceAllString(t, "$1")
+	switch t {
+	case "boolean", "bool":
+		t = "tinyint"
+	case "integer":
+		t = "int"
+	}
+	ci.Type = t
+
+	d := trimDefault(ci.Default)
+	switch strings.ToLower(d) {
+	case "now()", "current_timestamp", "current_timestamp()":
+		d = "current_timestamp"
+	case "true":
+		d = "1"
+	case "false":
+		d = "0"
+	}
+	if strings.HasPrefix(strings.ToLower(d), "timestamp(") {
+		d = trimDefault(d[len("timestamp"):])
+	}
+	ci.Default = d
+	return ci
+}
+
+// readTableNames reads the names of the tables in the schema set via
+// SetSchema, or the connected MySQL database.
+func (myf *MySQLFlavor) readTableNames() ([]string, error) {
+
+	qs := "SELECT table_name FROM information_schema.TABLES WHERE table_schema = ? AND table_type = 'BASE TABLE' ORDER BY table_name;"
+	dbName := myf.schema
+	if dbName == "" {
+		dbName = myf.GetDBName()
+	}
+	myf.QsLog(qs, dbName)
+
+	tns := make([]string, 0)
+	err := myf.db.Select(&tns, qs, dbName)
+	if err != nil {
+		return nil, err
+	}
+	return tns, nil
+}
+
+// readIndexes reads the indexes of table tn from the information_schema
+// of the connected MySQL database.  The primary-key index and the indexes
+// backing constraints and foreign-keys are not included.
+func (myf *MySQLFlavor) readIndexes(tn string) (map[string]IndexInfo, error) {
+
+	qs := "SELECT s.index_name, s.non_unique, s.column_name FROM information_schema.STATISTICS s " +
+		"WHERE s.table_schema = ? AND s.table_name = ? AND s.index_name <> 'PRIMARY' " +
+		"AND NOT EXISTS (SELECT 1 FROM information_schema.TABLE_CONSTRAINTS c WHERE c.table_schema = s.table_schema " +
+		"AND c.table_name = s.table_name AND c.constraint_name = s.index_name) " +
+		"ORDER BY s.index_name, s.seq_in_index;"
+	dbName, bn := myf.splitSchema(tn)
+	myf.QsLog(qs, dbName, bn)
+
+	rows, err := myf.db.Query(qs, dbName, bn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ind := make(map[string]IndexInfo)
+	for rows.Next() {
+		var in, cn string
+		var nonUnique int
+		err = rows.Scan(&in, &nonUnique, &cn)
+		if err != nil {
+			return nil, err
+		}
+		addIndexField(ind, tn, in, nonUnique == 0, cn)
+	}
+	return ind, rows.Err()
+}
+
+// readForeignKeys reads the foreign-keys of table tn from the
+// information_schema of the connected MySQL database.
+func (myf *MySQLFlavor) readForeignKeys(tn string) ([]FKeyInfo, error) {
+
+	qs := "SELECT constraint_name, column_name, referenced_table_name, referenced_column_name FROM information_schema.KEY_COLUMN_USAGE " +
+		"WHERE table_schema = ? AND table_name = ? AND referenced_table_name IS NOT NULL ORDER BY constraint_name, ordinal_position;"
+	dbName, bn := myf.splitSchema(tn)
+	myf.QsLog(qs, dbName, bn)
+
+	rows, err := myf.db.Query(qs, dbName, bn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fks := make([]FKeyInfo, 0)
+	for rows.Next() {
+		fk := FKeyInfo{FromTable: tn}
+		err = rows.Scan(&fk.FKeyName, &fk.FromField, &fk.RefTable, &fk.RefField)
+		if err != nil {
+			return nil, err
+		}
+		fks = append(fks, fk)
+	}
+	return fks, rows.Err()
+}
+
+// readChecks reads the check constraints of table tn from the
+// information_schema of the connected MySQL database.  CHECK_CONSTRAINTS
+// is not available before MySQL 8.0.16, which parses but ignores check
+// constraints, so no checks are reported in that case.
+func (myf *MySQLFlavor) readChecks(tn string) (map[string]string, error) {
+
+	qs := "SELECT cc.constraint_name, cc.check_clause FROM information_schema.CHECK_CONSTRAINTS cc " +
+		"INNER JOIN information_schema.TABLE_CONSTRAINTS tc ON tc.constraint_schema = cc.constraint_schema AND tc.constraint_name = cc.constraint_name " +
+		"WHERE tc.table_schema = ? AND tc.table_name = ? AND tc.constraint_type = 'CHECK';"
+	dbName, bn := myf.splitSchema(tn)
+	myf.QsLog(qs, dbName, bn)
+
+	checks := make(map[string]string)
+	rows, err := myf.db.Query(qs, dbName, bn)
+	if err != nil {
+		return checks, nil
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cn, def string
+		err = rows.Scan(&cn, &def)
+		if err != nil {
+			return nil, err
+		}
+		checks[cn] = def
+	}
+	return checks, rows.Err()
+}
+
+// renameColumnSQL returns the statement renaming column from of table tn
+// to column to.
+func (myf *MySQLFlavor) renameColumnSQL(tn, from, to string) string {
+	return "ALTER TABLE " + tn + " RENAME COLUMN " + from + " TO " + to + ";"
+}
+
+// renameTableSQL returns the statements renaming table from to table to.
+func (myf *MySQLFlavor) renameTableSQL(from, to string) ([]string, error) {
+	return []string{"RENAME TABLE " + from + " TO " + to + ";"}, nil
+}
+
+// alterColumns brings the existing columns of table tn in line with the
+// model captured in tc.  Type, nullability and default changes are
+// applied by way of MODIFY COLUMN, columns that are no longer part of
+// the model are dealt with according to the destructive-change policy.
+func (myf *MySQLFlavor) alterColumns(tn string, tc TblComponents) error {
+
+	dbCols, err := myf.readColumns(tn)
+	if err != nil {
+		return err
+	}
+
+	deltas := myf.diffColumns(myf.modelColumns(tc), dbCols, myf.normalizeColumn)
+	deltas, err = myf.applyDestructivePolicy(tn, deltas)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range myf.alterColumnSQL(tn, deltas) {
+		_, err = myf.Exec(s)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// alterColumnSQL returns the statements that apply the column deltas
+// to table tn.
+func (myf *MySQLFlavor) alterColumnSQL(tn string, deltas []colDelta) []string {
+
+	qt := myf.GetDBQuote()
+	alterSchema := "ALTER TABLE " + quotedTableName(tn, qt)
+	var stmts []string
+	for _, d := range deltas {
+		switch d.op {
+		case "DROP":
+			stmts = append(stmts, alterSchema+" DROP COLUMN "+qt+d.name+qt+";")
+
+		case "ALTER":
+			// MODIFY COLUMN restates the complete column definition
+			colSchema := " MODIFY COLUMN " + qt + d.name + qt + " " + d.to.Type
+			if !d.to.Nullable {
+				if d.nullChg && d.to.Default != "" {
+					stmts = append(stmts, "UPDATE "+quotedTableName(tn, qt)+" SET "+qt+d.name+qt+" = "+d.to.Default+" WHERE "+qt+d.name+qt+" IS NULL;")
+				}
+				colSchema = colSchema + " NOT NULL"
+			}
+			if d.to.Default != "" {
+				colSchema = colSchema + " DEFAULT " + d.to.Default
+			}
+			stmts = append(stmts, alterSchema+colSchema+";")
+		}
+	}
+	return stmts
+}
+
 // DropIndex drops the specfied index on the connected database.
 func (myf *MySQLFlavor) DropIndex(tn string, in string) error {
 
 	if myf.ExistsIndex(tn, in) {
-		indexSchema := "DROP INDEX " + in + " ON " + tn + ";"
+		indexSchema := myf.dropIndexSQL(tn, in)
 		myf.ProcessSchema(indexSchema)
 		return nil
 	}
 	return nil
 }
 
+// dropIndexSQL returns the statement used to drop index in on table tn.
+func (myf *MySQLFlavor) dropIndexSQL(tn string, in string) string {
+	return "DROP INDEX " + in + " ON " + tn + ";"
+}
+
 // DestructiveResetTables drops tables on the MySQL db if they exist,
 // as well as any related objects such as sequences.  this is
 // useful if you wish to regenerated your table and the
@@ -517,6 +923,77 @@ func (myf *MySQLFlavor) DestructiveResetTables(i ...interface{}) error {
 	return nil
 }
 
+// PlanCreateTables returns the ordered list of statements that CreateTables
+// would execute on the connected MySQL db, without making any changes.
+func (myf *MySQLFlavor) PlanCreateTables(i ...interface{}) ([]string, error) {
+	return myf.runPlan(func() error { return myf.CreateTables(i...) })
+}
+
+// PlanAlterTables returns the ordered list of statements that AlterTables
+// would execute on the connected MySQL db, without making any changes.
+func (myf *MySQLFlavor) PlanAlterTables(i ...interface{}) ([]string, error) {
+	return myf.runPlan(func() error { return myf.AlterTables(i...) })
+}
+
+// PlanDropTables returns the ordered list of statements that DropTables
+// would execute on the connected MySQL db, without making any changes.
+func (myf *MySQLFlavor) PlanDropTables(i ...interface{}) ([]string, error) {
+	return myf.runPlan(func() error { return myf.DropTables(i...) })
+}
+
+// PlanDestructiveResetTables returns the ordered list of statements that
+// DestructiveResetTables would execute on the connected MySQL db, without
+// making any changes.
+func (myf *MySQLFlavor) PlanDestructiveResetTables(i ...interface{}) ([]string, error) {
+	return myf.runPlan(func() error { return myf.DestructiveResetTables(i...) })
+}
+
+// SnapshotTables returns a snapshot of the MySQL tables described by
+// the models.
+func (myf *MySQLFlavor) SnapshotTables(i ...interface{}) (Snapshot, error) {
+	return myf.snapshotTables(myf, i...)
+}
+
+// PlanMigration returns the statements required to migrate the MySQL
+// tables from their state in the connected db, or in snapshot from, to
+// the models, along with the statements required to revert the migration.
+func (myf *MySQLFlavor) PlanMigration(from *Snapshot, i ...interface{}) ([]string, []string, error) {
+	return myf.planMigration(myf, from, i...)
+}
+
+// GenerateModels reads the named MySQL tables, or all tables if no names
+// are provided, and returns the source of a go file in package pkg holding
+// a sqac-tagged model for each of them.
+func (myf *MySQLFlavor) GenerateModels(pkg string, tn ...string) ([]byte, error) {
+	return myf.generateModels(myf, pkg, tn...)
+}
+
+// DescribeTable reads the columns, primary-key, indexes, foreign-keys and
+// sequences of MySQL table tn.
+func (myf *MySQLFlavor) DescribeTable(tn string) (TableInfo, error) {
+	return myf.describeTable(myf, tn)
+}
+
+// tableComponents returns the components of the MySQL table described
+// by model ent.
+func (myf *MySQLFlavor) tableComponents(tn string, ent interface{}) TblComponents {
+	return myf.buildTablSchema(tn, ent)
+}
+
+// addColumnSQL returns the statement used to add column ci to table tn.
+func (myf *MySQLFlavor) addColumnSQL(tn string, ci ColumnInfo) string {
+
+	qt := myf.GetDBQuote()
+	colSchema := "ALTER TABLE " + quotedTableName(tn, qt) + " ADD COLUMN " + qt + ci.Name + qt + " " + ci.Type
+	if !ci.Nullable {
+		colSchema = colSchema + " NOT NULL"
+	}
+	if ci.Default != "" {
+		colSchema = colSchema + " DEFAULT " + ci.Default
+	}
+	return colSchema + ";"
+}
+
 // AlterSequenceStart may be used to make changes to the start value
 // of the named auto_increment field in the MySQL database.  Note
 // that this is intended to deal with auto-incrementing primary
@@ -542,7 +1019,8 @@ func (myf *MySQLFlavor) GetNextSequenceValue(name string) (int, error) {
 	seq := 0
 	if myf.ExistsTable(name) {
 
-		seqQuery := "SELECT `AUTO_INCREMENT` FROM  INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = '" + myf.GetDBName() + "' AND TABLE_NAME = '" + name + "';"
+		dbName, bn := myf.splitSchema(name)
+		seqQuery := "SELECT `AUTO_INCREMENT` FROM  INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = '" + dbName + "' AND TABLE_NAME = '" + bn + "';"
 		myf.QsLog(seqQuery)
 
 		err := myf.db.QueryRow(seqQuery).Scan(&seq)
@@ -573,9 +1051,9 @@ func (myf *MySQLFlavor) DropForeignKey(i interface{}, ft, fkn string) error {
 func (myf *MySQLFlavor) ExistsForeignKeyByName(i interface{}, fkn string) (bool, error) {
 
 	var count uint64
-	tn := common.GetTableName(i)
+	dbName, tn := myf.splitSchema(myf.TableName(i))
 
-	fkQuery := "SELECT COUNT(*) FROM information_schema.table_constraints WHERE constraint_name='" + fkn + "' AND table_name='" + tn + "';"
+	fkQuery := "SELECT COUNT(*) FROM information_schema.table_constraints WHERE constraint_name='" + fkn + "' AND table_name='" + tn + "' AND table_schema='" + dbName + "';"
 	myf.QsLog(fkQuery)
 
 	err := myf.Get(&count, fkQuery)
@@ -600,6 +1078,58 @@ func (myf *MySQLFlavor) ExistsForeignKeyByFields(i interface{}, ft, rt, ff, rf s
 	return myf.ExistsForeignKeyByName(i, fkn)
 }
 
+// CallProcedure calls MySQL stored procedure name, passing the fields of in
+// as its IN parameters in field order.  If out points to a slice of
+// structs, the result set of the procedure is read into it.  If out points
+// to a struct, its fields are passed as OUT parameters following the IN
+// parameters, and receive the values returned by the procedure.
+func (myf *MySQLFlavor) CallProcedure(name string, in interface{}, out interface{}) error {
+
+	ps, err := procParams(in)
+	if err != nil {
+		return err
+	}
+	outs, err := procOutParams(out)
+	if err != nil {
+		return err
+	}
+
+	params := make([]string, 0)
+	for range ps {
+		params = append(params, "?")
+	}
+	if len(outs) == 0 {
+		qs := "CALL " + name + "(" + strings.Join(params, ", ") + ");"
+		return myf.queryProcedure(out, qs, procArgs(ps)...)
+	}
+
+	// OUT parameters are returned in session variables, which are read
+	// on the connection that called the procedure.
+	vars := make([]string, 0)
+	for _, p := range outs {
+		params = append(params, "@"+p.name)
+		vars = append(vars, "@"+p.name+" AS "+p.name)
+	}
+
+	ctx := context.Background()
+	conn, err := myf.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	qs := "CALL " + name + "(" + strings.Join(params, ", ") + ");"
+	myf.QsLog(qs, procArgs(ps)...)
+	_, err = conn.ExecContext(ctx, qs, procArgs(ps)...)
+	if err != nil {
+		return err
+	}
+
+	qs = "SELECT " + strings.Join(vars, ", ") + ";"
+	myf.QsLog(qs)
+	return conn.GetContext(ctx, out, qs)
+}
+
 //================================================================
 // CRUD ops
 //================================================================
@@ -619,30 +1149,34 @@ func (myf *MySQLFlavor) Create(ent interface{}) error {
 
 	// build the mysql insert query
 	insQuery := "INSERT INTO " + info.tn + " " + info.fList + " VALUES " + info.vList + ";"
-	myf.QsLog(insQuery)
+	myf.QsLog(insQuery, info.args...)
 
 	// clear the source data - deals with non-persistet columns
 	e := reflect.ValueOf(info.ent).Elem()
 	e.Set(reflect.Zero(e.Type()))
 
 	// attempt the insert and read the result back into info.resultMap
-	result, err := myf.db.Exec(insQuery)
+	result, err := myf.db.Exec(insQuery, info.args...)
 	if err != nil {
 		return err
 	}
 
-	lastID, err := result.LastInsertId()
-	if err != nil {
-		return err
+	var lastID int64
+	if info.incKeyName != "" {
+		lastID, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
 	}
 
-	selQuery := "SELECT * FROM " + info.tn + " WHERE " + info.incKeyName + " = " + strconv.FormatInt(lastID, 10) + " LIMIT 1;"
+	selQuery := "SELECT * FROM " + info.tn + " WHERE " + info.keyCondition(lastID) + " LIMIT 1;"
 	myf.QsLog(selQuery)
 
-	err = myf.db.QueryRowx(selQuery).StructScan(info.ent) // .MapScan(info.resultMap) // SliceScan
+	err = myf.structScan(myf.db.QueryRowx(selQuery), info.ent) // .MapScan(info.resultMap) // SliceScan
 	if err != nil {
 		return err
 	}
+	myf.readTimes(info.ent)
 	info.entValue = reflect.ValueOf(info.ent)
 	return nil
 }
@@ -678,20 +1212,21 @@ func (myf *MySQLFlavor) Update(ent interface{}) error {
 	keyList = strings.TrimSuffix(keyList, " AND")
 
 	colList := ""
-	for k, v := range info.fldMap {
+	for _, k := range info.fields() {
+		v := info.fldMap[k]
 		colList = fmt.Sprintf("%s %s = %s, ", colList, k, v)
 	}
 	colList = strings.TrimSuffix(colList, ", ")
 
 	updQuery := "UPDATE " + info.tn + " SET " + colList + " WHERE " + keyList + ";"
-	myf.QsLog(updQuery)
+	myf.QsLog(updQuery, info.args...)
 
 	// clear the source data - deals with non-persistet columns
 	e := reflect.ValueOf(info.ent).Elem()
 	e.Set(reflect.Zero(e.Type()))
 
 	// attempt the update and check for errors
-	_, err = myf.db.Exec(updQuery)
+	_, err = myf.db.Exec(updQuery, info.args...)
 	if err != nil {
 		return err
 	}
@@ -700,10 +1235,11 @@ func (myf *MySQLFlavor) Update(ent interface{}) error {
 	selQuery := "SELECT * FROM " + info.tn + " WHERE " + keyList + " LIMIT 1;"
 	myf.QsLog(selQuery)
 
-	err = myf.db.QueryRowx(selQuery).StructScan(info.ent) // .MapScan(info.resultMap) // SliceScan
+	err = myf.structScan(myf.db.QueryRowx(selQuery), info.ent) // .MapScan(info.resultMap) // SliceScan
 	if err != nil {
 		return err
 	}
+	myf.readTimes(info.ent)
 	info.entValue = reflect.ValueOf(info.ent)
 	return nil
 }
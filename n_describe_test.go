@@ -0,0 +1,95 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac/common"
+)
+
+// TestDescribeTable
+//
+// Create tables pier and slipway and check the columns,
+// primary-key, indexes, foreign-keys and sequences reported
+// for table slipway.
+func TestDescribeTable(t *testing.T) {
+
+	type Pier struct {
+		ID   uint64 `db:"id" sqac:"primary_key:inc"`
+		Name string `db:"name" sqac:"nullable:false"`
+	}
+
+	type Slipway struct {
+		ID     uint64  `db:"id" sqac:"primary_key:inc"`
+		PierID uint64  `db:"pier_id" sqac:"nullable:false;fkey:pier(id)"`
+		Code   string  `db:"code" sqac:"nullable:false;default:S1;index:unique"`
+		Note   *string `db:"note" sqac:"nullable:true"`
+	}
+
+	err := Handle.DropTables(Slipway{}, Pier{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Pier{}, Slipway{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Slipway{}, Pier{})
+
+	tn := common.GetTableName(Slipway{})
+	ti, err := Handle.DescribeTable(tn)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	if ti.Name != tn || len(ti.Columns) != 4 {
+		t.Fatalf("expected 4 columns for table %s - got %v", tn, ti)
+	}
+	for i, cn := range []string{"id", "pier_id", "code", "note"} {
+		if !strings.EqualFold(ti.Columns[i].Name, cn) {
+			t.Errorf("expected column %s at position %d - got %s", cn, i, ti.Columns[i].Name)
+		}
+	}
+	if !ti.Columns[0].PrimaryKey || !ti.Columns[0].Identity {
+		t.Errorf("expected column id to be an identity primary-key - got %v", ti.Columns[0])
+	}
+	if ti.Columns[2].Nullable || !strings.Contains(ti.Columns[2].Default, "S1") {
+		t.Errorf("expected column code to be not nullable with default S1 - got %v", ti.Columns[2])
+	}
+	if !ti.Columns[3].Nullable {
+		t.Errorf("expected column note to be nullable - got %v", ti.Columns[3])
+	}
+
+	if len(ti.PrimaryKey) != 1 || !strings.EqualFold(ti.PrimaryKey[0], "id") {
+		t.Errorf("expected primary-key id - got %v", ti.PrimaryKey)
+	}
+
+	in := "idx_" + tn + "_code"
+	ix, ok := ti.Indexes[in]
+	if !ok {
+		ix, ok = ti.Indexes[strings.ToUpper(in)]
+	}
+	if !ok || !ix.Unique || len(ix.IndexFields) != 1 || !strings.EqualFold(ix.IndexFields[0], "code") {
+		t.Errorf("expected unique index %s on column code - got %v", in, ti.Indexes)
+	}
+	if len(ti.Indexes) != 1 {
+		t.Errorf("expected a single index on table %s - got %v", tn, ti.Indexes)
+	}
+
+	if len(ti.ForeignKeys) != 1 {
+		t.Fatalf("expected a single foreign-key on table %s - got %v", tn, ti.ForeignKeys)
+	}
+	fk := ti.ForeignKeys[0]
+	if !strings.EqualFold(fk.FromField, "pier_id") || !strings.EqualFold(fk.RefTable, "pier") || !strings.EqualFold(fk.RefField, "id") {
+		t.Errorf("expected foreign-key pier_id -> pier(id) - got %v", fk)
+	}
+
+	if len(ti.Sequences) != 1 || !strings.EqualFold(ti.Sequences[0].Column, "id") || ti.Sequences[0].Name == "" {
+		t.Errorf("expected a sequence feeding column id - got %v", ti.Sequences)
+	}
+
+	_, err = Handle.DescribeTable("no_such_table")
+	if err == nil {
+		t.Errorf("expected an error for a missing table - got none")
+	}
+}
@@ -0,0 +1,94 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac"
+	"github.com/1414C/sqac/common"
+)
+
+// TestRenameColumn
+//
+// Rename column name of table barge to title via a
+// renamed_from tag and drop column cargo with the
+// destructive-change policy set to apply.  Check that
+// the data held in the renamed column survives.
+func TestRenameColumn(t *testing.T) {
+
+	tn := ""
+	{
+		type Barge struct {
+			ID    uint64 `db:"id" sqac:"primary_key:inc"`
+			Name  string `db:"name" sqac:"nullable:false;default:none"`
+			Cargo string `db:"cargo" sqac:"nullable:true"`
+		}
+
+		err := Handle.DropTables(Barge{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		err = Handle.CreateTables(Barge{})
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		tn = common.GetTableName(Barge{})
+	}
+
+	_, err := Handle.Exec("INSERT INTO " + tn + " (name, cargo) VALUES ('b1', 'coal')")
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	type Barge struct {
+		ID    uint64 `db:"id" sqac:"primary_key:inc"`
+		Title string `db:"title" sqac:"nullable:false;default:none;renamed_from:name"`
+	}
+	defer Handle.DropTables(Barge{})
+
+	// the plan renames the column rather than adding a new one
+	plan, err := Handle.PlanAlterTables(Barge{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	p := strings.ToLower(strings.Join(plan, "\n"))
+	if !strings.Contains(p, "rename") || strings.Contains(p, "add column title") || strings.Contains(p, "add title") {
+		t.Errorf("expected column name to be renamed to title in the plan - got %v", plan)
+	}
+	if Handle.ExistsColumn(tn, "title") {
+		t.Errorf("column title should not have been created by PlanAlterTables")
+	}
+
+	Handle.SetDestructivePolicy(sqac.DestructiveApply)
+	defer Handle.SetDestructivePolicy(sqac.DestructiveSkip)
+
+	err = Handle.AlterTables(Barge{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	if !Handle.ExistsColumn(tn, "title") || Handle.ExistsColumn(tn, "name") {
+		t.Errorf("expected column name of table %s to have been renamed to title", tn)
+	}
+	if Handle.ExistsColumn(tn, "cargo") {
+		t.Errorf("expected column cargo of table %s to have been dropped", tn)
+	}
+
+	var barges []Barge
+	_, err = Handle.GetEntitiesCP(&barges, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(barges) != 1 || barges[0].Title != "b1" {
+		t.Errorf("expected the content of column name to be retained in column title - got %v", barges)
+	}
+
+	// a second pass finds nothing to rename
+	plan, err = Handle.PlanAlterTables(Barge{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if strings.Contains(strings.ToLower(strings.Join(plan, "\n")), "rename") {
+		t.Errorf("expected no further renames - got %v", plan)
+	}
+}
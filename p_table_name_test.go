@@ -0,0 +1,148 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac/common"
+)
+
+// Lighter names its table via a TableName() method
+type Lighter struct {
+	ID uint64 `db:"id" sqac:"primary_key:inc"`
+}
+
+func (l *Lighter) TableName() string {
+	return "barge_lighter"
+}
+
+// TestTableName
+//
+// Check the table-names determined for models with a
+// TableName() method, a struct-level table tag, and for
+// models named by their go type under the snake_case and
+// prefix options.
+func TestTableName(t *testing.T) {
+
+	type Tug struct {
+		_  struct{} `sqac:"table:harbour_tug"`
+		ID uint64   `db:"id" sqac:"primary_key:inc"`
+	}
+
+	type GetCmdTest struct {
+		ID uint64 `db:"id" sqac:"primary_key:inc"`
+	}
+
+	for _, c := range []struct {
+		i  interface{}
+		tn string
+	}{
+		{Lighter{}, "barge_lighter"},
+		{&Lighter{}, "barge_lighter"},
+		{&[]Lighter{}, "barge_lighter"},
+		{Tug{}, "harbour_tug"},
+		{&[]*Tug{}, "harbour_tug"},
+		{GetCmdTest{}, "getcmdtest"},
+	} {
+		if tn := common.GetTableName(c.i); tn != c.tn {
+			t.Errorf("expected table-name %s for %T - got %s", c.tn, c.i, tn)
+		}
+	}
+
+	naming := common.TableNaming{SnakeCase: true, Prefix: "app_"}
+	if tn := naming.TableName(GetCmdTest{}); tn != "app_get_cmd_test" {
+		t.Errorf("expected table-name app_get_cmd_test - got %s", tn)
+	}
+	if tn := naming.TableName(Tug{}); tn != "harbour_tug" {
+		t.Errorf("expected explicit table-name harbour_tug to be used as-is - got %s", tn)
+	}
+
+	// the naming options belong to the handle
+	defer Handle.SetTableNaming(Handle.GetTableNaming())
+	Handle.SetTableNaming(naming)
+	if tn := Handle.TableName(GetCmdTest{}); !strings.HasSuffix(tn, "app_get_cmd_test") {
+		t.Errorf("expected table-name app_get_cmd_test on the handle - got %s", tn)
+	}
+	if tn := common.GetTableName(GetCmdTest{}); tn != "getcmdtest" {
+		t.Errorf("expected the default table-name getcmdtest to be unaffected by the handle - got %s", tn)
+	}
+}
+
+// TestRenameTable
+//
+// Rename table hull to keel via a struct-level renamed_from
+// hint and check that the data held in the table survives.
+func TestRenameTable(t *testing.T) {
+
+	type Hull struct {
+		ID   uint64 `db:"id" sqac:"primary_key:inc"`
+		Name string `db:"name" sqac:"nullable:false;default:none"`
+	}
+
+	type Keel struct {
+		_    struct{} `sqac:"renamed_from:hull"`
+		ID   uint64   `db:"id" sqac:"primary_key:inc"`
+		Name string   `db:"name" sqac:"nullable:false;default:none"`
+	}
+
+	err := Handle.DropTables(Hull{}, Keel{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Hull{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Hull{}, Keel{})
+
+	err = Handle.Create(&Hull{Name: "h1"})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	// the plan renames the table rather than creating a new one
+	plan, err := Handle.PlanAlterTables(Keel{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	p := strings.ToLower(strings.Join(plan, "\n"))
+	if !strings.Contains(p, "rename") || strings.Contains(p, "create") {
+		t.Errorf("expected table hull to be renamed to keel in the plan - got %v", plan)
+	}
+	if Handle.ExistsTable("keel") {
+		t.Errorf("table keel should not have been created by PlanAlterTables")
+	}
+
+	err = Handle.AlterTables(Keel{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if !Handle.ExistsTable("keel") || Handle.ExistsTable("hull") {
+		t.Errorf("expected table hull to have been renamed to keel")
+	}
+
+	var keels []Keel
+	_, err = Handle.GetEntitiesCP(&keels, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(keels) != 1 || keels[0].Name != "h1" {
+		t.Errorf("expected the content of table hull to be retained in table keel - got %v", keels)
+	}
+
+	// inserts continue on the renamed table
+	k := Keel{Name: "k2"}
+	err = Handle.Create(&k)
+	if err != nil || k.ID == 0 {
+		t.Errorf("expected an insert into table keel - got %v, %v", k, err)
+	}
+
+	// a second pass finds nothing to rename
+	plan, err = Handle.PlanAlterTables(Keel{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if strings.Contains(strings.ToLower(strings.Join(plan, "\n")), "rename") {
+		t.Errorf("expected no further renames - got %v", plan)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -67,6 +68,13 @@ func (pf *PostgresFlavor) createTables(calledFromAlter bool, i ...interface{}) (
 
 	// get the list of table Model{}s
 	di := i[0].([]interface{})
+
+	// create the schemas of the tables if need be
+	err := pf.createTableSchemas(pf, di...)
+	if err != nil {
+		return nil, err
+	}
+
 	for t, ent := range di {
 
 		ftr := reflect.TypeOf(ent)
@@ -75,14 +83,15 @@ func (pf *PostgresFlavor) createTables(calledFromAlter bool, i ...interface{}) (
 		}
 
 		// determine the table name
-		tn := common.GetTableName(di[t])
+		tn := pf.TableName(di[t])
 		if tn == "" {
 			return nil, fmt.Errorf("unable to determine table name in pf.createTables")
 		}
 
 		// if the table is found to exist, skip the creation
-		// and move on to the next table in the list.
-		if pf.ExistsTable(tn) {
+		// and move on to the next table in the list.  tables
+		// dropped earlier in a plan are considered absent.
+		if !pf.absentInPlan(tn) && pf.ExistsTable(tn) {
 			if pf.log {
 				log.Printf("createTable - table %s exists - skipping...\n", tn)
 			}
@@ -94,15 +103,15 @@ func (pf *PostgresFlavor) createTables(calledFromAlter bool, i ...interface{}) (
 		pf.QsLog(tc.tblSchema)
 
 		// create the table on the db
-		pf.db.MustExec(tc.tblSchema)
+		pf.mustExec(tc.tblSchema)
 		for _, sq := range tc.seq {
 			start, _ := strconv.Atoi(sq.Value)
 			pf.AlterSequenceStart(sq.Name, start)
 		}
 
 		// create the table indices
-		for k, in := range tc.ind {
-			pf.CreateIndex(k, in)
+		for _, k := range indexNames(tc.ind) {
+			pf.CreateIndex(k, tc.ind[k])
 		}
 
 		// add foreign-key information to the buffer
@@ -148,6 +157,8 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 	pKeys := ""
 	var sequences []common.SqacPair
 	indexes := make(map[string]IndexInfo)
+	constraints := make(map[string]ConstraintInfo)
+	columns := make([]ColComponents, 0)
 	fKeys := make([]FKeyInfo, 0)
 	tableSchema := "CREATE TABLE " + tn + " ("
 
@@ -180,6 +191,8 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 			continue
 		}
 
+		col.uType = userType(fd, "varchar")
+
 		switch fd.UnderGoType { // fd.GoType {
 		case "uint", "uint8", "uint16", "uint32", "uint64",
 			"int", "int8", "int16", "int32", "int64", "rune", "byte":
@@ -202,16 +215,26 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 
 					if p.Value == "inc" {
 
-						// warn that user-specified db_type type will be ignored
-						if col.uType != "" {
-							log.Printf("WARNING: %s auto-incrementing primary-key field %s has user-specified db_type: %s  user-type is ignored. \n", common.GetTableName(ent), col.fName, col.uType)
-							col.uType = ""
-						}
 						if strings.Contains(fd.UnderGoType, "64") {
 							col.fType = "bigserial"
 						} else {
 							col.fType = "serial"
 						}
+
+						// map user-specified integer types onto their serial
+						// counterparts; other user-types are ignored
+						switch strings.ToLower(col.uType) {
+						case "":
+						case "smallint", "int2":
+							col.uType = "smallserial"
+						case "integer", "int", "int4":
+							col.uType = "serial"
+						case "bigint", "int8":
+							col.uType = "bigserial"
+						default:
+							log.Printf("WARNING: %s auto-incrementing primary-key field %s has user-specified db_type: %s  user-type is ignored. \n", pf.TableName(ent), col.fName, col.uType)
+							col.uType = ""
+						}
 					}
 
 				case "start":
@@ -220,13 +243,10 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 						panic(err)
 					}
 					if seqName == "" && start > 0 {
-						seqName = tn + "_" + fd.FName + "_seq"
+						seqName = qualifiedName(tn, bareTableName(tn)+"_"+fd.FName+"_seq")
 						sequences = append(sequences, common.SqacPair{Name: seqName, Value: p.Value})
 					}
 
-				// case "type":
-				// 	col.uType = p.Value
-
 				case "default":
 					col.fDefault = "DEFAULT " + p.Value
 
@@ -238,8 +258,13 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 				case "constraint":
 					if p.Value == "unique" {
 						col.fUniqueConstraint = "UNIQUE"
+					} else {
+						constraints = pf.processConstraintTag(constraints, tn, fd.FName, p.Value)
 					}
 
+				case "check":
+					constraints = pf.processCheckTag(constraints, tn, fd.FName, p.Value)
+
 				case "index":
 					switch p.Value {
 					case "non-unique":
@@ -261,9 +286,16 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 			}
 			fldef[idx].FType = col.fType
 
-		case "string":
+		case "string", common.ValuerType, common.JSONType:
 
 			col.fType = "text"
+			if fd.UnderGoType == common.JSONType {
+				col.fType = "jsonb"
+			}
+			if fd.TagValue("primary_key") == "uuid" {
+				col.fType = "uuid"
+				col.fDefault = "DEFAULT gen_random_uuid()"
+			}
 
 			for _, p := range fd.SqacPairs {
 				switch p.Name {
@@ -271,9 +303,6 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 					col.fPrimaryKey = "PRIMARY KEY"
 					pKeys = pKeys + fd.FName + ","
 
-				case "type":
-					col.uType = p.Value
-
 				case "nullable":
 					if p.Value == "false" {
 						col.fNullable = "NOT NULL"
@@ -285,8 +314,16 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 				case "constraint":
 					if p.Value == "unique" {
 						col.fUniqueConstraint = "UNIQUE"
+					} else {
+						constraints = pf.processConstraintTag(constraints, tn, fd.FName, p.Value)
 					}
 
+				case "check":
+					constraints = pf.processCheckTag(constraints, tn, fd.FName, p.Value)
+
+				case "enum":
+					constraints = pf.processEnumTag(constraints, tn, fd.FName, p.Value)
+
 				case "index":
 
 					switch p.Value {
@@ -309,8 +346,11 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 			}
 			fldef[idx].FType = col.fType
 
-		case "float32", "float64":
+		case "float32", "float64", common.DecimalType:
 			col.fType = "numeric"
+			if fd.UnderGoType == common.DecimalType {
+				col.fType = decimalType("numeric", fd)
+			}
 
 			for _, p := range fd.SqacPairs {
 				switch p.Name {
@@ -329,8 +369,13 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 				case "constraint":
 					if p.Value == "unique" {
 						col.fUniqueConstraint = "UNIQUE"
+					} else {
+						constraints = pf.processConstraintTag(constraints, tn, fd.FName, p.Value)
 					}
 
+				case "check":
+					constraints = pf.processCheckTag(constraints, tn, fd.FName, p.Value)
+
 				case "index":
 					switch p.Value {
 					case "non-unique":
@@ -368,6 +413,16 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 						col.fNullable = "NOT NULL"
 					}
 
+				case "constraint":
+					if p.Value == "unique" {
+						col.fUniqueConstraint = "UNIQUE"
+					} else {
+						constraints = pf.processConstraintTag(constraints, tn, fd.FName, p.Value)
+					}
+
+				case "check":
+					constraints = pf.processCheckTag(constraints, tn, fd.FName, p.Value)
+
 				case "index":
 					switch p.Value {
 					case "non-unique":
@@ -389,8 +444,50 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 			}
 			fldef[idx].FType = col.fType
 
-		case "time.Time":
-			col.fType = "timestamp with time zone"
+		case common.ArrayType:
+			col.fType = pgArrayType(fd)
+
+			for _, p := range fd.SqacPairs {
+				switch p.Name {
+				case "nullable":
+					if p.Value == "false" {
+						col.fNullable = "NOT NULL"
+					}
+
+				case "default":
+					col.fDefault = "DEFAULT '" + p.Value + "'"
+
+				default:
+
+				}
+			}
+			fldef[idx].FType = col.fType
+
+		case "[]byte":
+			col.fType = "bytea"
+
+			for _, p := range fd.SqacPairs {
+				switch p.Name {
+				case "nullable":
+					if p.Value == "false" {
+						col.fNullable = "NOT NULL"
+					}
+
+				default:
+
+				}
+			}
+			fldef[idx].FType = col.fType
+
+		case "time.Time", common.DateType, common.TimeOfDayType:
+			switch fd.UnderGoType {
+			case common.DateType:
+				col.fType = "date"
+			case common.TimeOfDayType:
+				col.fType = "time"
+			default:
+				col.fType = "timestamp with time zone"
+			}
 
 			for _, p := range fd.SqacPairs {
 				switch p.Name {
@@ -405,6 +502,16 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 						col.fDefault = "DEFAULT " + "make_timestamptz(9999, 12, 31, 23, 59, 59.9)"
 					}
 
+				case "constraint":
+					if p.Value == "unique" {
+						col.fUniqueConstraint = "UNIQUE"
+					} else {
+						constraints = pf.processConstraintTag(constraints, tn, fd.FName, p.Value)
+					}
+
+				case "check":
+					constraints = pf.processCheckTag(constraints, tn, fd.FName, p.Value)
+
 				case "index":
 					switch p.Value {
 					case "non-unique":
@@ -451,6 +558,13 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 			panic(err)
 		}
 
+		if col.uType != "" {
+			fldef[idx].FType = col.uType
+		}
+
+		// retain the column definition for comparison in AlterTables
+		columns = append(columns, col)
+
 		// add the current column to the schema
 		if col.uType != "" {
 			tableSchema = tableSchema + col.fName + " " + col.uType
@@ -469,6 +583,11 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 		tableSchema = tableSchema + ", "
 	}
 
+	// add the table-level unique and check constraints
+	for _, cn := range pf.constraintNames(constraints) {
+		tableSchema = tableSchema + pf.constraintClause(cn, constraints[cn]) + ", "
+	}
+
 	if tableSchema != "" && pKeys == "" {
 		tableSchema = strings.TrimSpace(tableSchema)
 		tableSchema = strings.TrimSuffix(tableSchema, ",")
@@ -476,7 +595,7 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 	}
 	if tableSchema != "" && pKeys != "" {
 		pKeys = strings.TrimSuffix(pKeys, ",")
-		tableSchema = tableSchema + "CONSTRAINT " + strings.ToLower(tn) + "_pkey PRIMARY KEY (" + pKeys + ") );"
+		tableSchema = tableSchema + "CONSTRAINT " + strings.ToLower(bareTableName(tn)) + "_pkey PRIMARY KEY (" + pKeys + ") );"
 	}
 
 	// fill the return structure passing out the CREATE TABLE schema, and component info
@@ -485,6 +604,8 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 		flDef:     fldef,
 		seq:       sequences,
 		ind:       indexes,
+		cons:      constraints,
+		cols:      columns,
 		fkey:      fKeys,
 		pk:        pKeys,
 		err:       err,
@@ -500,25 +621,35 @@ func (pf *PostgresFlavor) buildTablSchema(tn string, ent interface{}) TblCompone
 // by pf.DB.
 func (pf *PostgresFlavor) CreateTables(i ...interface{}) error {
 
+	// views are created once the tables are in place
+	i, views := splitViews(i)
+
 	// call createTables specifying that the call has not originated
 	// from within the AlterTables(...) method.
 	_, err := pf.createTables(false, i)
 	if err != nil {
 		return err
 	}
-	return nil
+	return pf.createViews(pf, views...)
 }
 
 // DropTables drops tables on the postgres database referenced
 // by pf.DB.
 func (pf *PostgresFlavor) DropTables(i ...interface{}) error {
 
+	// views are dropped ahead of the tables they select from
+	i, views := splitViews(i)
+	err := pf.dropViews(pf, views...)
+	if err != nil {
+		return err
+	}
+
 	dropSchema := ""
 
 	for t := range i {
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := pf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in pf.DropTables")
 		}
@@ -530,12 +661,13 @@ func (pf *PostgresFlavor) DropTables(i ...interface{}) error {
 			if pf.log {
 				log.Printf("table %s exists - adding to drop schema...\n", tn)
 			}
-			dropSchema = dropSchema + "DROP TABLE IF EXISTS " + tn + ";"
+			// submit 1 at a time so that each statement is planned separately
+			dropSchema = dropSchema + pf.dropTableSQL(tn)
+			pf.ProcessSchema(dropSchema)
+			pf.dropInPlan(tn)
+			dropSchema = ""
 		}
 	}
-	if dropSchema != "" {
-		pf.ProcessSchema(dropSchema)
-	}
 	return nil
 }
 
@@ -544,6 +676,7 @@ func (pf *PostgresFlavor) DropTables(i ...interface{}) error {
 func (pf *PostgresFlavor) AlterTables(i ...interface{}) error {
 
 	var err error
+	i, views := splitViews(i)
 	fkBuffer := make([]ForeignKeyBuffer, 0)
 	ci := make([]interface{}, 0)
 	ai := make([]interface{}, 0)
@@ -552,11 +685,21 @@ func (pf *PostgresFlavor) AlterTables(i ...interface{}) error {
 	for t := range i {
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := pf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in pf.AlterTables")
 		}
 
+		// rename the table of a model tagged with renamed_from; the
+		// renamed table cannot be examined in plan mode.
+		renamed, err := pf.renameTable(pf, tn, i[t])
+		if err != nil {
+			return err
+		}
+		if renamed && pf.planning {
+			continue
+		}
+
 		// if the table does not exist, add the Model{} definition to
 		// the CreateTables buffer (ci).
 		// if the table does exist, add the Model{} definition to  the
@@ -582,7 +725,7 @@ func (pf *PostgresFlavor) AlterTables(i ...interface{}) error {
 	for t, ent := range ai {
 
 		// determine the table name
-		tn := common.GetTableName(ai[t])
+		tn := pf.TableName(ai[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in pf.AlterTables")
 		}
@@ -590,6 +733,12 @@ func (pf *PostgresFlavor) AlterTables(i ...interface{}) error {
 		// build the alter-table schema and get its components
 		tc := pf.buildTablSchema(tn, ai[t])
 
+		// rename the columns of fields tagged with renamed_from
+		renamed, err := pf.renameColumns(pf, tn, tc)
+		if err != nil {
+			return err
+		}
+
 		// go through the latest version of the model and check each
 		// field against its definition in the database.
 		alterSchema := "ALTER TABLE IF EXISTS " + tn
@@ -597,7 +746,7 @@ func (pf *PostgresFlavor) AlterTables(i ...interface{}) error {
 
 		for _, fd := range tc.flDef {
 			// new columns first
-			if !pf.ExistsColumn(tn, fd.FName) && fd.NoDB == false {
+			if !pf.ExistsColumn(tn, fd.FName) && !renamed[fd.FName] && fd.NoDB == false {
 
 				colSchema := "ADD COLUMN " + fd.FName + " " + fd.FType
 				for _, p := range fd.SqacPairs {
@@ -607,7 +756,7 @@ func (pf *PostgresFlavor) AlterTables(i ...interface{}) error {
 						panic(fmt.Errorf("aborting - cannot add a primary-key (table-field %s-%s) through migration", tn, fd.FName))
 
 					case "default":
-						if fd.UnderGoType == "string" {
+						if fd.UnderGoType == "string" || fd.UnderGoType == common.ValuerType || fd.UnderGoType == common.JSONType {
 							colSchema = colSchema + " DEFAULT '" + p.Value + "'"
 						} else {
 							colSchema = colSchema + " DEFAULT " + p.Value
@@ -635,13 +784,46 @@ func (pf *PostgresFlavor) AlterTables(i ...interface{}) error {
 			pf.ProcessSchema(alterSchema)
 		}
 
+		// bring the existing columns in line with the model
+		err = pf.alterColumns(tn, tc)
+		if err != nil {
+			return err
+		}
+
 		// add indexes if required
-		for k, v := range tc.ind {
+		for _, k := range indexNames(tc.ind) {
+			v := tc.ind[k]
 			if !pf.ExistsIndex(v.TableName, k) {
 				pf.CreateIndex(k, v)
 			}
 		}
 
+		// add unique and check constraints if required
+		for _, cn := range pf.constraintNames(tc.cons) {
+			if !pf.ExistsConstraint(tn, cn) {
+				err = pf.CreateConstraint(cn, tc.cons[cn])
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// re-create the check constraints whose expression has changed
+		changed, err := pf.changedChecks(pf, tn, tc.cons)
+		if err != nil {
+			return err
+		}
+		for _, cn := range changed {
+			err = pf.DropConstraint(tn, cn)
+			if err != nil {
+				return err
+			}
+			err = pf.CreateConstraint(cn, tc.cons[cn])
+			if err != nil {
+				return err
+			}
+		}
+
 		// add to the list of foreign-keys
 		for _, v := range tc.fkey {
 			fkb := ForeignKeyBuffer{
@@ -668,7 +850,7 @@ func (pf *PostgresFlavor) AlterTables(i ...interface{}) error {
 			}
 		}
 	}
-	return nil
+	return pf.createViews(pf, views...)
 }
 
 // DestructiveResetTables drops tables on the db if they exist,
@@ -688,14 +870,92 @@ func (pf *PostgresFlavor) DestructiveResetTables(i ...interface{}) error {
 	return nil
 }
 
-// ExistsTable checks the public schema of the connected Postgres
-// DB for the existence of the provided table name.  Note that
-// the use of to_regclass(<obj_name>) checks for the existence of
-// *any* object in the public schema that has that name.  If obj/name
+// PlanCreateTables returns the ordered list of statements that CreateTables
+// would execute on the connected Postgres db, without making any changes.
+func (pf *PostgresFlavor) PlanCreateTables(i ...interface{}) ([]string, error) {
+	return pf.runPlan(func() error { return pf.CreateTables(i...) })
+}
+
+// PlanAlterTables returns the ordered list of statements that AlterTables
+// would execute on the connected Postgres db, without making any changes.
+func (pf *PostgresFlavor) PlanAlterTables(i ...interface{}) ([]string, error) {
+	return pf.runPlan(func() error { return pf.AlterTables(i...) })
+}
+
+// PlanDropTables returns the ordered list of statements that DropTables
+// would execute on the connected Postgres db, without making any changes.
+func (pf *PostgresFlavor) PlanDropTables(i ...interface{}) ([]string, error) {
+	return pf.runPlan(func() error { return pf.DropTables(i...) })
+}
+
+// PlanDestructiveResetTables returns the ordered list of statements that
+// DestructiveResetTables would execute on the connected Postgres db, without
+// making any changes.
+func (pf *PostgresFlavor) PlanDestructiveResetTables(i ...interface{}) ([]string, error) {
+	return pf.runPlan(func() error { return pf.DestructiveResetTables(i...) })
+}
+
+// SnapshotTables returns a snapshot of the Postgres tables described by
+// the models.
+func (pf *PostgresFlavor) SnapshotTables(i ...interface{}) (Snapshot, error) {
+	return pf.snapshotTables(pf, i...)
+}
+
+// PlanMigration returns the statements required to migrate the Postgres
+// tables from their state in the connected db, or in snapshot from, to
+// the models, along with the statements required to revert the migration.
+func (pf *PostgresFlavor) PlanMigration(from *Snapshot, i ...interface{}) ([]string, []string, error) {
+	return pf.planMigration(pf, from, i...)
+}
+
+// GenerateModels reads the named Postgres tables, or all tables if no names
+// are provided, and returns the source of a go file in package pkg holding
+// a sqac-tagged model for each of them.
+func (pf *PostgresFlavor) GenerateModels(pkg string, tn ...string) ([]byte, error) {
+	return pf.generateModels(pf, pkg, tn...)
+}
+
+// DescribeTable reads the columns, primary-key, indexes, foreign-keys and
+// sequences of Postgres table tn.
+func (pf *PostgresFlavor) DescribeTable(tn string) (TableInfo, error) {
+	return pf.describeTable(pf, tn)
+}
+
+// tableComponents returns the components of the Postgres table described
+// by model ent.
+func (pf *PostgresFlavor) tableComponents(tn string, ent interface{}) TblComponents {
+	return pf.buildTablSchema(tn, ent)
+}
+
+// dropTableSQL returns the statement used to drop table tn.
+func (pf *PostgresFlavor) dropTableSQL(tn string) string {
+	return "DROP TABLE IF EXISTS " + tn + ";"
+}
+
+// addColumnSQL returns the statement used to add column ci to table tn.
+func (pf *PostgresFlavor) addColumnSQL(tn string, ci ColumnInfo) string {
+
+	colSchema := "ALTER TABLE IF EXISTS " + tn + " ADD COLUMN " + ci.Name + " " + ci.Type
+	if ci.Default != "" {
+		colSchema = colSchema + " DEFAULT " + ci.Default
+	}
+	if !ci.Nullable {
+		colSchema = colSchema + " NOT NULL"
+	}
+	return colSchema + ";"
+}
+
+// ExistsTable checks the schema of table tn (public by default) of the
+// connected Postgres DB for the existence of the provided table name.
+// Note that the use of to_regclass(<obj_name>) checks for the existence
+// of *any* object in the schema that has that name.  If obj/name
 // consistency is maintained, this approach is fine.
 func (pf *PostgresFlavor) ExistsTable(tn string) bool {
 
-	reqQuery := "SELECT to_regclass('public." + tn + "');"
+	if !strings.Contains(tn, ".") {
+		tn = "public." + tn
+	}
+	reqQuery := "SELECT to_regclass('" + tn + "');"
 	pf.QsLog(reqQuery)
 
 	rows, err := pf.db.Query(reqQuery)
@@ -715,6 +975,66 @@ func (pf *PostgresFlavor) ExistsTable(tn string) bool {
 	return false
 }
 
+// ExistsView checks the connected Postgres database for the presence of
+// view vn.
+func (pf *PostgresFlavor) ExistsView(vn string) bool {
+
+	n := 0
+	sn, bn := splitTableName(vn)
+	qs := "SELECT COUNT(*) FROM information_schema.views WHERE table_schema = COALESCE(NULLIF($1, ''), CURRENT_SCHEMA()) AND table_name = $2;"
+	pf.QsLog(qs, sn, bn)
+	pf.db.QueryRow(qs, sn, bn).Scan(&n)
+	return n > 0
+}
+
+// DropView drops view vn on the connected Postgres database if it exists.
+func (pf *PostgresFlavor) DropView(vn string) error {
+	return pf.dropView(pf, vn)
+}
+
+// ForTenant returns a handle sharing the connection to the Postgres database, whose
+// table names are routed to the tables of tenant via the tenant resolver.
+func (pf *PostgresFlavor) ForTenant(tenant string) PublicDB {
+	t := *pf
+	t.tenant = tenant
+	return &t
+}
+
+// CreateSchema creates schema sn on the connected Postgres database if
+// it does not exist.
+func (pf *PostgresFlavor) CreateSchema(sn string) error {
+	return pf.createSchema(pf, sn)
+}
+
+// DropSchema drops schema sn on the connected Postgres database if it
+// exists.  Schemas still holding objects are not dropped.
+func (pf *PostgresFlavor) DropSchema(sn string) error {
+	return pf.dropSchema(pf, sn)
+}
+
+// ExistsSchema checks the connected Postgres database for the presence
+// of schema sn.
+func (pf *PostgresFlavor) ExistsSchema(sn string) bool {
+
+	n := 0
+	pf.QsLog("SELECT count(*) FROM information_schema.schemata WHERE schema_name = ?", sn)
+	err := pf.db.QueryRow("SELECT count(*) FROM information_schema.schemata WHERE schema_name = $1", sn).Scan(&n)
+	if err != nil {
+		return false
+	}
+	return n > 0
+}
+
+// createSchemaSQL returns the statement used to create schema sn.
+func (pf *PostgresFlavor) createSchemaSQL(sn string) (string, error) {
+	return "CREATE SCHEMA IF NOT EXISTS " + sn + ";", nil
+}
+
+// dropSchemaSQL returns the statement used to drop schema sn.
+func (pf *PostgresFlavor) dropSchemaSQL(sn string) (string, error) {
+	return "DROP SCHEMA IF EXISTS " + sn + " RESTRICT;", nil
+}
+
 // ExistsColumn checks for the existence of the specified
 // table-column checking for the column name. this is
 // rather incomplete, but in many cases where there is
@@ -726,8 +1046,9 @@ func (pf *PostgresFlavor) ExistsTable(tn string) bool {
 func (pf *PostgresFlavor) ExistsColumn(tn string, cn string) bool {
 
 	n := 0
-	pf.QsLog("SELECT count(*) FROM INFORMATION_SCHEMA.columns WHERE table_name = ? AND column_name = ? AND table_schema = CURRENT_SCHEMA()", tn, cn)
-	row := pf.db.QueryRow("SELECT count(*) FROM INFORMATION_SCHEMA.columns WHERE table_name = $1 AND column_name = $2 AND table_schema = CURRENT_SCHEMA()", tn, cn)
+	sn, bn := splitTableName(tn)
+	pf.QsLog("SELECT count(*) FROM INFORMATION_SCHEMA.columns WHERE table_name = ? AND column_name = ? AND table_schema = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA())", bn, cn, sn)
+	row := pf.db.QueryRow("SELECT count(*) FROM INFORMATION_SCHEMA.columns WHERE table_name = $1 AND column_name = $2 AND table_schema = COALESCE(NULLIF($3, ''), CURRENT_SCHEMA())", bn, cn, sn)
 	if row != nil {
 		row.Scan(&n)
 		if n > 0 {
@@ -737,14 +1058,317 @@ func (pf *PostgresFlavor) ExistsColumn(tn string, cn string) bool {
 	return false
 }
 
+// pgCastRegexp matches a literal default value followed by a type-cast
+var pgCastRegexp = regexp.MustCompile(`^('(?:[^']|'')*'|[^:()']+)::[a-z ]+(\([0-9, ]+\))?(\[\])?$`)
+
+// readColumns reads the column definitions of table tn from the
+// information_schema of the connected Postgres database.
+func (pf *PostgresFlavor) readColumns(tn string) ([]ColumnInfo, error) {
+
+	qs := "SELECT c.column_name, c.data_type, c.udt_name, COALESCE(c.character_maximum_length, 0), COALESCE(c.numeric_precision, 0), COALESCE(c.numeric_scale, 0), " +
+		"c.is_nullable, COALESCE(c.column_default, ''), " +
+		"(SELECT count(*) FROM information_schema.key_column_usage k INNER JOIN information_schema.table_constraints t " +
+		"ON k.constraint_name = t.constraint_name AND k.table_schema = t.table_schema " +
+		"WHERE t.constraint_type = 'PRIMARY KEY' AND k.table_name = c.table_name AND k.column_name = c.column_name AND k.table_schema = c.table_schema) " +
+		"FROM information_schema.columns c WHERE c.table_name = $1 AND c.table_schema = COALESCE(NULLIF($2, ''), CURRENT_SCHEMA()) ORDER BY c.ordinal_position"
+	sn, bn := splitTableName(tn)
+	pf.QsLog(qs, bn, sn)
+
+	rows, err := pf.db.Query(qs, bn, sn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols := make([]ColumnInfo, 0)
+	for rows.Next() {
+		var ci ColumnInfo
+		var length, precision, scale, pk int
+		var udt, nullable string
+		err = rows.Scan(&ci.Name, &ci.Type, &udt, &length, &precision, &scale, &nullable, &ci.Default, &pk)
+		if err != nil {
+			return nil, err
+		}
+		if length > 0 {
+			ci.Type = ci.Type + "(" + strconv.Itoa(length) + ")"
+		}
+		if ci.Type == "ARRAY" {
+			ci.Type = pgUDTArrayType(udt)
+		}
+		if ci.Type == "numeric" && precision > 0 {
+			ci.Type = ci.Type + "(" + strconv.Itoa(precision) + "," + strconv.Itoa(scale) + ")"
+		}
+		ci.Nullable = nullable == "YES"
+		ci.PrimaryKey = pk > 0
+		ci.Identity = strings.HasPrefix(ci.Default, "nextval(")
+		cols = append(cols, ci)
+	}
+	return pf.planColumns(tn, cols), rows.Err()
+}
+
+// normalizeColumn maps the Postgres type aliases and default expression
+// formats onto a common representation for column comparison.
+func (pf *PostgresFlavor) normalizeColumn(ci ColumnInfo) ColumnInfo {
+
+	t := strings.ToLower(strings.TrimSpace(ci.Type))
+	switch {
+	case t == "serial", t == "int", t == "int4":
+		t = "integer"
+	case t == "smallserial", t == "int2":
+		t = "smallint"
+	case t == "bigserial", t == "int8":
+		t = "bigint"
+	case t == "bool":
+		t = "boolean"
+	case t == "timestamptz":
+		t = "timestamp with time zone"
+	case t == "time without time zone":
+		t = "time"
+	case t == "decimal":
+		t = "numeric"
+	case strings.HasPrefix(t, "decimal("):
+		t = "numeric" + strings.TrimPrefix(t, "decimal")
+	case strings.HasPrefix(t, "varchar"):
+		t = "character varying" + strings.TrimPrefix(t, "varchar")
+	case strings.HasPrefix(t, "char("):
+		t = "character" + strings.TrimPrefix(t, "char")
+	}
+	ci.Type = t
+
+	// strip type-casts such as 'YYC'::text or '0'::numeric
+	ci.Default = trimDefault(pgCastRegexp.ReplaceAllString(ci.Default, "$1"))
+	return ci
+}
+
+// readTableNames reads the names of the tables in the schema set via
+// SetSchema, or the current schema, of the connected Postgres database.
+func (pf *PostgresFlavor) readTableNames() ([]string, error) {
+
+	qs := "SELECT table_name FROM information_schema.tables WHERE table_schema = COALESCE(NULLIF($1, ''), CURRENT_SCHEMA()) AND table_type = 'BASE TABLE' ORDER BY table_name"
+	pf.QsLog(qs, pf.schema)
+
+	tns := make([]string, 0)
+	err := pf.db.Select(&tns, qs, pf.schema)
+	if err != nil {
+		return nil, err
+	}
+	return tns, nil
+}
+
+// readIndexes reads the indexes of table tn from the catalog of the
+// connected Postgres database.  The primary-key index and the indexes
+// backing constraints are not included.
+func (pf *PostgresFlavor) readIndexes(tn string) (map[string]IndexInfo, error) {
+
+	qs := "SELECT i.relname, ix.indisunique, a.attname FROM pg_class t " +
+		"INNER JOIN pg_namespace n ON n.oid = t.relnamespace " +
+		"INNER JOIN pg_index ix ON ix.indrelid = t.oid " +
+		"INNER JOIN pg_class i ON i.oid = ix.indexrelid " +
+		"INNER JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(ix.indkey) " +
+		"WHERE t.relname = $1 AND n.nspname = COALESCE(NULLIF($2, ''), CURRENT_SCHEMA()) AND NOT ix.indisprimary " +
+		"AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = ix.indexrelid) " +
+		"ORDER BY i.relname, array_position(ix.indkey::int2[], a.attnum)"
+	sn, bn := splitTableName(tn)
+	pf.QsLog(qs, bn, sn)
+
+	rows, err := pf.db.Query(qs, bn, sn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ind := make(map[string]IndexInfo)
+	for rows.Next() {
+		var in, cn string
+		var unique bool
+		err = rows.Scan(&in, &unique, &cn)
+		if err != nil {
+			return nil, err
+		}
+		addIndexField(ind, tn, in, unique, cn)
+	}
+	return ind, rows.Err()
+}
+
+// readForeignKeys reads the foreign-keys of table tn from the
+// information_schema of the connected Postgres database.
+func (pf *PostgresFlavor) readForeignKeys(tn string) ([]FKeyInfo, error) {
+
+	qs := "SELECT t.constraint_name, k.column_name, c.table_name, c.column_name FROM information_schema.table_constraints t " +
+		"INNER JOIN information_schema.key_column_usage k ON k.constraint_name = t.constraint_name AND k.table_schema = t.table_schema " +
+		"INNER JOIN information_schema.constraint_column_usage c ON c.constraint_name = t.constraint_name AND c.table_schema = t.table_schema " +
+		"WHERE t.constraint_type = 'FOREIGN KEY' AND t.table_name = $1 AND t.table_schema = COALESCE(NULLIF($2, ''), CURRENT_SCHEMA()) " +
+		"ORDER BY t.constraint_name, k.ordinal_position"
+	sn, bn := splitTableName(tn)
+	pf.QsLog(qs, bn, sn)
+
+	rows, err := pf.db.Query(qs, bn, sn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fks := make([]FKeyInfo, 0)
+	for rows.Next() {
+		fk := FKeyInfo{FromTable: tn}
+		err = rows.Scan(&fk.FKeyName, &fk.FromField, &fk.RefTable, &fk.RefField)
+		if err != nil {
+			return nil, err
+		}
+		fks = append(fks, fk)
+	}
+	return fks, rows.Err()
+}
+
+// readChecks reads the names and definitions of the check constraints
+// of table tn from the catalog of the connected Postgres database.
+func (pf *PostgresFlavor) readChecks(tn string) (map[string]string, error) {
+
+	qs := "SELECT con.conname, pg_get_constraintdef(con.oid) FROM pg_constraint con " +
+		"INNER JOIN pg_class c ON c.oid = con.conrelid INNER JOIN pg_namespace n ON n.oid = c.relnamespace " +
+		"WHERE con.contype = 'c' AND c.relname = $1 AND n.nspname = COALESCE(NULLIF($2, ''), CURRENT_SCHEMA())"
+	sn, bn := splitTableName(tn)
+	pf.QsLog(qs, bn, sn)
+
+	rows, err := pf.db.Query(qs, bn, sn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checks := make(map[string]string)
+	for rows.Next() {
+		var cn, def string
+		err = rows.Scan(&cn, &def)
+		if err != nil {
+			return nil, err
+		}
+		checks[cn] = def
+	}
+	return checks, rows.Err()
+}
+
+// pgNextvalRegexp matches the sequence name in the default expression of a
+// serial column; nextval('depot_depot_num_seq'::regclass) for example.
+var pgNextvalRegexp = regexp.MustCompile(`^nextval\('([^']+)'`)
+
+// sequenceName returns the name of the sequence feeding serial column ci
+// of table tn, as per the default expression of the column.
+func (pf *PostgresFlavor) sequenceName(tn string, ci ColumnInfo) string {
+
+	m := pgNextvalRegexp.FindStringSubmatch(ci.Default)
+	if m == nil {
+		return qualifiedName(tn, bareTableName(tn)+"_"+ci.Name+"_seq")
+	}
+	return m[1]
+}
+
+// renameColumnSQL returns the statement renaming column from of table tn
+// to column to.
+func (pf *PostgresFlavor) renameColumnSQL(tn, from, to string) string {
+	return "ALTER TABLE " + tn + " RENAME COLUMN " + from + " TO " + to + ";"
+}
+
+// renameTableSQL returns the statements renaming table from to table to.
+// The sequences of serial columns follow their columns.
+func (pf *PostgresFlavor) renameTableSQL(from, to string) ([]string, error) {
+	return []string{"ALTER TABLE " + from + " RENAME TO " + bareTableName(to) + ";"}, nil
+}
+
+// alterColumns brings the existing columns of table tn in line with the
+// model captured in tc.  Type, nullability and default changes are
+// applied, columns that are no longer part of the model are dealt with
+// according to the destructive-change policy.
+func (pf *PostgresFlavor) alterColumns(tn string, tc TblComponents) error {
+
+	dbCols, err := pf.readColumns(tn)
+	if err != nil {
+		return err
+	}
+
+	deltas := pf.diffColumns(pf.modelColumns(tc), dbCols, pf.normalizeColumn)
+	deltas, err = pf.applyDestructivePolicy(tn, deltas)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range pf.alterColumnSQL(tn, deltas) {
+		_, err = pf.Exec(s)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// alterColumnSQL returns the statements that apply the column deltas
+// to table tn.
+func (pf *PostgresFlavor) alterColumnSQL(tn string, deltas []colDelta) []string {
+
+	alterSchema := "ALTER TABLE IF EXISTS " + tn
+	var stmts []string
+	for _, d := range deltas {
+		switch d.op {
+		case "DROP":
+			stmts = append(stmts, alterSchema+" DROP COLUMN "+d.name+";")
+
+		case "ALTER":
+			// the existing default may not be castable to the new type
+			if d.typeChg {
+				if d.from.Default != "" {
+					stmts = append(stmts, alterSchema+" ALTER COLUMN "+d.name+" DROP DEFAULT;")
+					d.dfltChg = true
+				}
+				stmts = append(stmts, alterSchema+" ALTER COLUMN "+d.name+" TYPE "+d.to.Type+" USING "+d.name+"::"+d.to.Type+";")
+			}
+			if d.dfltChg {
+				if d.to.Default != "" {
+					stmts = append(stmts, alterSchema+" ALTER COLUMN "+d.name+" SET DEFAULT "+d.to.Default+";")
+				} else if !d.typeChg {
+					stmts = append(stmts, alterSchema+" ALTER COLUMN "+d.name+" DROP DEFAULT;")
+				}
+			}
+			if d.nullChg {
+				if d.to.Nullable {
+					stmts = append(stmts, alterSchema+" ALTER COLUMN "+d.name+" DROP NOT NULL;")
+				} else {
+					if d.to.Default != "" {
+						stmts = append(stmts, "UPDATE "+tn+" SET "+d.name+" = "+d.to.Default+" WHERE "+d.name+" IS NULL;")
+					}
+					stmts = append(stmts, alterSchema+" ALTER COLUMN "+d.name+" SET NOT NULL;")
+				}
+			}
+		}
+	}
+	return stmts
+}
+
 // ExistsIndex checks the connected Postgres database for the presence
 // of the specified index - assuming that the index-type has not
 // been adjusted...
 func (pf *PostgresFlavor) ExistsIndex(tn string, in string) bool {
 
 	n := 0
-	pf.QsLog("SELECT count(*) FROM pg_indexes WHERE tablename = ? AND indexname = ? AND schemaname = CURRENT_SCHEMA()", tn, in)
-	err := pf.db.QueryRow("SELECT count(*) FROM pg_indexes WHERE tablename = $1 AND indexname = $2 AND schemaname = CURRENT_SCHEMA()", tn, in).Scan(&n)
+	sn, bn := splitTableName(tn)
+	pf.QsLog("SELECT count(*) FROM pg_indexes WHERE tablename = ? AND indexname = ? AND schemaname = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA())", bn, in, sn)
+	err := pf.db.QueryRow("SELECT count(*) FROM pg_indexes WHERE tablename = $1 AND indexname = $2 AND schemaname = COALESCE(NULLIF($3, ''), CURRENT_SCHEMA())", bn, in, sn).Scan(&n)
+	if err != nil {
+		return false
+	}
+	if n > 0 {
+		return true
+	}
+	return false
+}
+
+// ExistsConstraint checks the connected Postgres database for the presence
+// of the named unique or check constraint on table tn.
+func (pf *PostgresFlavor) ExistsConstraint(tn string, cn string) bool {
+
+	n := 0
+	sn, bn := splitTableName(tn)
+	pf.QsLog("SELECT count(*) FROM information_schema.table_constraints WHERE table_name = ? AND constraint_name = ? AND table_schema = COALESCE(NULLIF(?, ''), CURRENT_SCHEMA())", bn, cn, sn)
+	err := pf.db.QueryRow("SELECT count(*) FROM information_schema.table_constraints WHERE table_name = $1 AND constraint_name = $2 AND table_schema = COALESCE(NULLIF($3, ''), CURRENT_SCHEMA())", bn, cn, sn).Scan(&n)
 	if err != nil {
 		return false
 	}
@@ -755,21 +1379,30 @@ func (pf *PostgresFlavor) ExistsIndex(tn string, in string) bool {
 }
 
 // DropIndex drops the specfied index on the connected Postgres database.
-// tn is ignored for Postgres.
+// tn is used only to determine the schema of the index.
 func (pf *PostgresFlavor) DropIndex(tn string, in string) error {
 
-	indexSchema := "DROP INDEX IF EXISTS " + in + ";"
+	indexSchema := pf.dropIndexSQL(tn, in)
 	pf.ProcessSchema(indexSchema)
 	return nil
 }
 
-// ExistsSequence checks the public schema of the connected Postgres
-// DB for the existence of the provided sequence name.
+// dropIndexSQL returns the statement used to drop index in.  Postgres
+// indexes live in the schema of their table.
+func (pf *PostgresFlavor) dropIndexSQL(tn string, in string) string {
+	return "DROP INDEX IF EXISTS " + qualifiedName(tn, in) + ";"
+}
+
+// ExistsSequence checks the connected Postgres DB for the existence of
+// the provided sequence name.  Sequence names may be qualified by their
+// schema; the current schema is checked otherwise.
 func (pf *PostgresFlavor) ExistsSequence(sn string) bool {
 
 	var params []interface{}
-	reqQuery := "SELECT relname FROM pg_class WHERE relkind = 'S' AND relname::name = $1"
-	params = append(params, sn)
+	reqQuery := "SELECT c.relname FROM pg_class c INNER JOIN pg_namespace n ON n.oid = c.relnamespace " +
+		"WHERE c.relkind = 'S' AND c.relname::name = $1 AND n.nspname = COALESCE(NULLIF($2, ''), CURRENT_SCHEMA())"
+	ssn, bsn := splitTableName(sn)
+	params = append(params, bsn, ssn)
 	pf.QsLog(reqQuery, params...)
 
 	rows, err := pf.db.Query(reqQuery, params...)
@@ -815,7 +1448,8 @@ func (pf *PostgresFlavor) AlterSequenceStart(sn string, start int) error {
 func (pf *PostgresFlavor) GetNextSequenceValue(name string) (int, error) {
 
 	// determine the column name of the primary key
-	pKeyQuery := "SELECT c.column_name, c.ordinal_position FROM information_schema.key_column_usage AS c LEFT JOIN information_schema.table_constraints AS t ON t.constraint_name = c.constraint_name WHERE t.table_name = '" + name + "' AND t.constraint_type = 'PRIMARY KEY';"
+	sn, bn := splitTableName(name)
+	pKeyQuery := "SELECT c.column_name, c.ordinal_position FROM information_schema.key_column_usage AS c LEFT JOIN information_schema.table_constraints AS t ON t.constraint_name = c.constraint_name AND t.table_schema = c.table_schema WHERE t.table_name = '" + bn + "' AND t.table_schema = COALESCE(NULLIF('" + sn + "', ''), CURRENT_SCHEMA()) AND t.constraint_type = 'PRIMARY KEY';"
 	var keyColumn string
 	var keyColumnPos int
 	pf.QsLog(pKeyQuery)
@@ -826,7 +1460,7 @@ func (pf *PostgresFlavor) GetNextSequenceValue(name string) (int, error) {
 	}
 
 	// Postgres sequences have format '<tablename>_<keyColumn>_seq'
-	seqName := name + "_" + keyColumn + "_seq"
+	seqName := qualifiedName(name, bn+"_"+keyColumn+"_seq")
 
 	if pf.ExistsSequence(seqName) {
 		seq := 0
@@ -847,9 +1481,9 @@ func (pf *PostgresFlavor) GetNextSequenceValue(name string) (int, error) {
 func (pf *PostgresFlavor) ExistsForeignKeyByName(i interface{}, fkn string) (bool, error) {
 
 	var count uint64
-	tn := common.GetTableName(i)
+	sn, tn := splitTableName(pf.TableName(i))
 
-	fkQuery := "SELECT COUNT(*) FROM information_schema.table_constraints WHERE constraint_name='" + fkn + "' AND table_name='" + tn + "';"
+	fkQuery := "SELECT COUNT(*) FROM information_schema.table_constraints WHERE constraint_name='" + fkn + "' AND table_name='" + tn + "' AND table_schema = COALESCE(NULLIF('" + sn + "', ''), CURRENT_SCHEMA());"
 	pf.QsLog(fkQuery)
 
 	err := pf.Get(&count, fkQuery)
@@ -874,6 +1508,21 @@ func (pf *PostgresFlavor) ExistsForeignKeyByFields(i interface{}, ft, rt, ff, rf
 	return pf.ExistsForeignKeyByName(i, fkn)
 }
 
+// CallProcedure calls Postgres function name, passing the fields of in as
+// its arguments in field order.  The rows returned by the function are
+// read into out, which may point to a slice of structs, or to a struct
+// for functions returning a single row or OUT parameters.  The result of
+// the function is discarded if out is nil.
+func (pf *PostgresFlavor) CallProcedure(name string, in interface{}, out interface{}) error {
+
+	ps, err := procParams(in)
+	if err != nil {
+		return err
+	}
+	qs := "SELECT * FROM " + name + "(" + placeholders(len(ps)) + ");"
+	return pf.queryProcedure(out, qs, procArgs(ps)...)
+}
+
 //================================================================
 // CRUD ops
 //================================================================
@@ -893,17 +1542,18 @@ func (pf *PostgresFlavor) Create(ent interface{}) error {
 
 	// build the postgres insert query
 	insQuery := "INSERT INTO " + info.tn + info.fList + " VALUES " + info.vList + " RETURNING *;"
-	pf.QsLog(insQuery)
+	pf.QsLog(insQuery, info.args...)
 
 	// clear the source data - deals with non-persistent columns
 	e := reflect.ValueOf(info.ent).Elem()
 	e.Set(reflect.Zero(e.Type()))
 
 	// attempt the insert and read the result back into info.resultMap
-	err = pf.db.QueryRowx(insQuery).StructScan(info.ent) //.MapScan(info.resultMap) // SliceScan
+	err = pf.structScan(pf.db.QueryRowx(insQuery, info.args...), info.ent) //.MapScan(info.resultMap) // SliceScan
 	if err != nil {
 		return err
 	}
+	pf.readTimes(info.ent)
 	info.entValue = reflect.ValueOf(info.ent)
 	return nil
 }
@@ -941,17 +1591,18 @@ func (pf *PostgresFlavor) Update(ent interface{}) error {
 	keyList = strings.TrimSuffix(keyList, " AND")
 	keyList = keyList + " RETURNING *;"
 	updQuery := "UPDATE " + info.tn + " SET " + info.fList + " = " + info.vList + " WHERE" + keyList
-	pf.QsLog(updQuery)
+	pf.QsLog(updQuery, info.args...)
 
 	// clear the source data - deals with non-persistet columns
 	e := reflect.ValueOf(info.ent).Elem()
 	e.Set(reflect.Zero(e.Type()))
 
 	// attempt the update and read result back into resultMap
-	err = pf.db.QueryRowx(updQuery).StructScan(info.ent) //.MapScan(info.resultMap) // SliceScan
+	err = pf.structScan(pf.db.QueryRowx(updQuery, info.args...), info.ent) //.MapScan(info.resultMap) // SliceScan
 	if err != nil {
 		return err
 	}
+	pf.readTimes(info.ent)
 	info.entValue = reflect.ValueOf(info.ent)
 	return nil
 }
@@ -0,0 +1,167 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+)
+
+// berthSchema holds the schema of table berth for the duration of
+// TestSchema
+var berthSchema = "main"
+
+// Berth is placed in the schema returned by its TableSchema method
+type Berth struct {
+	ID   uint64 `db:"id" sqac:"primary_key:inc"`
+	Name string `db:"name" sqac:"nullable:false;default:none;index:non-unique"`
+}
+
+func (b *Berth) TableSchema() string {
+	return berthSchema
+}
+
+// Bollard is placed in schema main via a struct-level schema tag
+type Bollard struct {
+	_  struct{} `sqac:"schema:main"`
+	ID uint64   `db:"id" sqac:"primary_key:inc"`
+}
+
+// TestSchema
+//
+// Create a named schema, and create, read and drop a table
+// placed in it.  SQLite schemas are attached database files,
+// so the test uses schema main on SQLite.
+func TestSchema(t *testing.T) {
+
+	sn := "sqac_dock"
+	err := Handle.CreateSchema(sn)
+	if err != nil {
+		if !Handle.ExistsSchema("main") {
+			t.Fatalf("%s", err.Error())
+		}
+		sn = "main"
+	} else {
+		defer func() {
+			err := Handle.DropSchema(sn)
+			if err != nil {
+				t.Errorf("%s", err.Error())
+			}
+			if Handle.ExistsSchema(sn) {
+				t.Errorf("expected schema %s to have been dropped", sn)
+			}
+		}()
+	}
+	if !Handle.ExistsSchema(sn) {
+		t.Fatalf("expected schema %s to exist", sn)
+	}
+
+	berthSchema = sn
+	defer func() { berthSchema = "main" }()
+	tn := sn + ".berth"
+
+	if n := Handle.TableName(Berth{}); n != tn {
+		t.Errorf("expected schema-qualified table-name %s - got %s", tn, n)
+	}
+	if n := Handle.TableName(Bollard{}); n != "main.bollard" {
+		t.Errorf("expected schema-qualified table-name main.bollard - got %s", n)
+	}
+
+	err = Handle.DropTables(Berth{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	plan, err := Handle.PlanCreateTables(Berth{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if !strings.Contains(strings.ToLower(strings.Join(plan, "\n")), sn) {
+		t.Errorf("expected the plan to create table berth in schema %s - got %v", sn, plan)
+	}
+
+	err = Handle.CreateTables(Berth{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Berth{})
+
+	if !Handle.ExistsTable(tn) {
+		t.Errorf("expected table %s to exist", tn)
+	}
+	if !Handle.ExistsIndex(tn, "idx_berth_name") {
+		t.Errorf("expected index idx_berth_name to exist on table %s", tn)
+	}
+	if sn != "main" && Handle.ExistsTable("berth") {
+		t.Errorf("table berth was not expected to exist outside of schema %s", sn)
+	}
+
+	b := Berth{Name: "b1"}
+	err = Handle.Create(&b)
+	if err != nil || b.ID == 0 {
+		t.Errorf("expected an insert into table %s - got %v, %v", tn, b, err)
+	}
+
+	var berths []Berth
+	_, err = Handle.GetEntitiesCP(&berths, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(berths) != 1 || berths[0].Name != "b1" {
+		t.Errorf("expected to read back the berth - got %v", berths)
+	}
+
+	ti, err := Handle.DescribeTable(tn)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(ti.Columns) != 2 {
+		t.Errorf("expected the columns of table %s - got %v", tn, ti.Columns)
+	}
+
+	// the handle-level schema applies to models without a schema
+	Handle.SetSchema(sn)
+	defer Handle.SetSchema("")
+	if n := Handle.TableName(Lighter{}); n != sn+".barge_lighter" {
+		t.Errorf("expected schema-qualified table-name %s.barge_lighter - got %s", sn, n)
+	}
+
+	// the renamed_from table is looked for in the schema of the model
+	type Skid struct {
+		ID   uint64 `db:"id" sqac:"primary_key:inc"`
+		Name string `db:"name" sqac:"nullable:false;default:none"`
+	}
+	type Sled struct {
+		_    struct{} `sqac:"renamed_from:skid"`
+		ID   uint64   `db:"id" sqac:"primary_key:inc"`
+		Name string   `db:"name" sqac:"nullable:false;default:none"`
+	}
+
+	err = Handle.DropTables(Skid{}, Sled{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Skid{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Skid{}, Sled{})
+	err = Handle.Create(&Skid{Name: "s1"})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	err = Handle.AlterTables(Sled{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if Handle.ExistsTable(sn+".skid") || !Handle.ExistsTable(sn+".sled") {
+		t.Errorf("expected table %s.skid to have been renamed to %s.sled", sn, sn)
+	}
+	var sleds []Sled
+	_, err = Handle.GetEntitiesCP(&sleds, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(sleds) != 1 || sleds[0].Name != "s1" {
+		t.Errorf("expected the data of table skid to be retained - got %v", sleds)
+	}
+}
@@ -0,0 +1,239 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac"
+)
+
+// Ferry is used to check the routing of tables by tenant
+type Ferry struct {
+	ID   uint64 `db:"id" sqac:"primary_key:inc"`
+	Name string `db:"name" sqac:"nullable:false;default:none"`
+}
+
+// TestTenantTableName
+//
+// Check the table-names of a model on tenant handles using
+// the default and the prefix tenant resolvers.
+func TestTenantTableName(t *testing.T) {
+
+	acme := Handle.ForTenant("acme")
+	if acme.GetTenant() != "acme" || Handle.GetTenant() != "" {
+		t.Errorf("expected the tenant to be set on the tenant handle only")
+	}
+	if tn := acme.TableName(Ferry{}); tn != "acme.ferry" {
+		t.Errorf("expected table-name acme.ferry - got %s", tn)
+	}
+	if tn := Handle.TableName(Ferry{}); tn != "ferry" {
+		t.Errorf("expected table-name ferry on the handle without tenant - got %s", tn)
+	}
+
+	acme.SetTenantResolver(sqac.TenantPrefix)
+	if tn := acme.TableName(Ferry{}); tn != "acme_ferry" {
+		t.Errorf("expected table-name acme_ferry - got %s", tn)
+	}
+}
+
+// TestTenantCRUD
+//
+// Provision the tables of two tenants from the same model
+// and check that the data of the tenants is kept apart.
+func TestTenantCRUD(t *testing.T) {
+
+	Handle.SetTenantResolver(sqac.TenantPrefix)
+	defer Handle.SetTenantResolver(nil)
+
+	for _, tenant := range []string{"acme", "bolt"} {
+		th := Handle.ForTenant(tenant)
+		err := th.DropTables(Ferry{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		err = th.CreateTables(Ferry{})
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		defer th.DropTables(Ferry{})
+
+		err = th.Create(&Ferry{Name: tenant})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+	}
+
+	if !Handle.ExistsTable("acme_ferry") || !Handle.ExistsTable("bolt_ferry") || Handle.ExistsTable("ferry") {
+		t.Errorf("expected a table per tenant")
+	}
+
+	for _, tenant := range []string{"acme", "bolt"} {
+		var ferries []Ferry
+		_, err := Handle.ForTenant(tenant).GetEntitiesCP(&ferries, nil, nil)
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		if len(ferries) != 1 || ferries[0].Name != tenant {
+			t.Errorf("expected to read the ferry of tenant %s only - got %v", tenant, ferries)
+		}
+	}
+}
+
+// Quay is referenced by the foreign-key of Mooring
+type Quay struct {
+	ID   uint64 `db:"id" sqac:"primary_key:inc"`
+	Name string `db:"name" sqac:"nullable:false;default:none"`
+}
+
+// Mooring references the quay of the same tenant
+type Mooring struct {
+	ID     uint64 `db:"id" sqac:"primary_key:inc"`
+	QuayID uint64 `db:"quay_id" sqac:"nullable:false;fkey:quay(id)"`
+}
+
+// QuayName is a view selecting from the quay of the tenant
+type QuayName struct {
+	Name string `db:"name"`
+}
+
+func (qn QuayName) ViewQuery() string {
+	return "SELECT name FROM {{table:quay}}"
+}
+
+// TestTenantForeignKey
+//
+// Provision tables linked by a foreign-key and a view for a
+// tenant using the prefix resolver, and check that the
+// foreign-key and the view refer to the tables of the tenant.
+func TestTenantForeignKey(t *testing.T) {
+
+	Handle.SetTenantResolver(sqac.TenantPrefix)
+	defer Handle.SetTenantResolver(nil)
+	acme := Handle.ForTenant("acme")
+
+	err := acme.DropTables(QuayName{}, Mooring{}, Quay{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	plan, err := acme.PlanCreateTables(Quay{}, Mooring{}, QuayName{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	ddl := strings.ToLower(strings.Join(plan, "\n"))
+	if !strings.Contains(ddl, "references acme_quay") || !strings.Contains(ddl, "from acme_quay") ||
+		strings.Contains(ddl, "references quay") || strings.Contains(ddl, "from quay") {
+		t.Errorf("expected the foreign-key and the view to refer to table acme_quay - got %v", plan)
+	}
+
+	err = acme.CreateTables(Quay{}, Mooring{}, QuayName{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer acme.DropTables(QuayName{}, Mooring{}, Quay{})
+
+	if Handle.ExistsTable("quay") || Handle.ExistsTable("mooring") {
+		t.Errorf("expected no tables outside of the tenant")
+	}
+	ok, err := acme.ExistsForeignKeyByFields(Mooring{}, "acme_mooring", "acme_quay", "quay_id", "id")
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if !ok {
+		t.Errorf("expected a foreign-key from acme_mooring to acme_quay")
+	}
+
+	plan, err = acme.PlanAlterTables(Quay{}, Mooring{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to the tables of tenant acme - got %v", plan)
+	}
+
+	q := Quay{Name: "north"}
+	err = acme.Create(&q)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	err = acme.Create(&Mooring{QuayID: q.ID})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	var names []QuayName
+	_, err = acme.GetEntitiesCP(&names, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(names) != 1 || names[0].Name != "north" {
+		t.Errorf("expected to read quay north via the view of tenant acme - got %v", names)
+	}
+}
+
+// TestTenantSchemaCreateTables
+//
+// Provision the tables of a tenant placed in a schema of its
+// own by the default resolver, and check the names derived
+// from the table-names.  The tables are created on postgres;
+// elsewhere the plan of an offline postgres handle is
+// checked only.
+func TestTenantSchemaCreateTables(t *testing.T) {
+
+	h, err := sqac.CreateOffline("postgres", false)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	plan, err := h.ForTenant("acme").PlanCreateTables(Quay{}, Mooring{}, QuayName{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	ddl := strings.ToLower(strings.Join(plan, "\n"))
+	for _, want := range []string{"create table acme.quay", "constraint quay_pkey", "references acme.quay(id)", "view acme.quayname as select name from acme.quay"} {
+		if !strings.Contains(ddl, want) {
+			t.Errorf("expected '%s' in the plan of tenant acme - got %v", want, plan)
+		}
+	}
+	if strings.Contains(ddl, "acme.quay_") || strings.Contains(ddl, "_acme.") {
+		t.Errorf("expected no schema-qualified derived names in the plan of tenant acme - got %v", plan)
+	}
+
+	if Handle.GetDBDriverName() != "postgres" {
+		t.Skipf("tenant schemas are created on postgres only")
+	}
+
+	err = Handle.CreateSchema("acme")
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropSchema("acme")
+
+	acme := Handle.ForTenant("acme")
+	err = acme.CreateTables(Quay{}, Mooring{}, QuayName{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer acme.DropTables(QuayName{}, Mooring{}, Quay{})
+
+	if !Handle.ExistsTable("acme.quay") || !Handle.ExistsTable("acme.mooring") {
+		t.Errorf("expected the tables of tenant acme in schema acme")
+	}
+
+	q := Quay{Name: "south"}
+	err = acme.Create(&q)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	err = acme.Create(&Mooring{QuayID: q.ID})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	var names []QuayName
+	_, err = acme.GetEntitiesCP(&names, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(names) != 1 || names[0].Name != "south" {
+		t.Errorf("expected to read quay south via the view of tenant acme - got %v", names)
+	}
+}
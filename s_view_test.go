@@ -0,0 +1,118 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac/common"
+)
+
+// Pier is the table selected from by the views
+type Pier struct {
+	ID     uint64 `db:"id" sqac:"primary_key:inc"`
+	Name   string `db:"name" sqac:"nullable:false;default:none"`
+	Length int    `db:"length" sqac:"nullable:false;default:0"`
+}
+
+// LongPier is a view defined via its ViewQuery() method
+type LongPier struct {
+	ID   uint64 `db:"id"`
+	Name string `db:"name"`
+}
+
+func (lp LongPier) ViewQuery() string {
+	return "SELECT id, name FROM pier WHERE length >= 100;"
+}
+
+// PierName is a view defined via common.RegisterView
+type PierName struct {
+	Name string `db:"name"`
+}
+
+func init() {
+	common.RegisterView(PierName{}, "SELECT name FROM pier")
+}
+
+// TestView
+//
+// Create views from models alongside the table they select
+// from, read from the views and check that the views are
+// read-only.
+func TestView(t *testing.T) {
+
+	err := Handle.DropTables(LongPier{}, PierName{}, Pier{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	plan, err := Handle.PlanCreateTables(Pier{}, LongPier{}, PierName{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) == 0 || !strings.Contains(strings.ToUpper(plan[len(plan)-1]), "VIEW") {
+		t.Errorf("expected the plan to create the views last - got %v", plan)
+	}
+
+	err = Handle.CreateTables(LongPier{}, PierName{}, Pier{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(LongPier{}, PierName{}, Pier{})
+
+	if !Handle.ExistsView("longpier") || !Handle.ExistsView("piername") {
+		t.Errorf("expected views longpier and piername to exist")
+	}
+	if Handle.ExistsView("pier") {
+		t.Errorf("table pier should not be reported as a view")
+	}
+
+	for _, p := range []Pier{{Name: "p1", Length: 50}, {Name: "p2", Length: 150}} {
+		err = Handle.Create(&p)
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+	}
+
+	var lps []LongPier
+	_, err = Handle.GetEntitiesCP(&lps, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(lps) != 1 || lps[0].Name != "p2" {
+		t.Errorf("expected to read pier p2 from view longpier - got %v", lps)
+	}
+
+	var pns []PierName
+	_, err = Handle.GetEntitiesCP(&pns, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(pns) != 2 {
+		t.Errorf("expected to read 2 piers from view piername - got %v", pns)
+	}
+
+	// views are read-only
+	if err = Handle.Create(&LongPier{Name: "p3"}); err == nil {
+		t.Errorf("expected Create on view longpier to fail")
+	}
+	if err = Handle.Update(&lps[0]); err == nil {
+		t.Errorf("expected Update on view longpier to fail")
+	}
+	if err = Handle.Delete(&lps[0]); err == nil {
+		t.Errorf("expected Delete on view longpier to fail")
+	}
+
+	// AlterTables replaces the views
+	err = Handle.AlterTables(Pier{}, LongPier{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	err = Handle.DropView("piername")
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if Handle.ExistsView("piername") {
+		t.Errorf("expected view piername to have been dropped")
+	}
+}
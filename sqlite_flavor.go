@@ -1,9 +1,11 @@
 package sqac
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -62,6 +64,15 @@ func (slf *SQLiteFlavor) GetDBName() (dbName string) {
 // by slf.DB.
 func (slf *SQLiteFlavor) CreateTables(i ...interface{}) error {
 
+	// views are created once the tables are in place
+	i, views := splitViews(i)
+
+	// check the schemas of the tables
+	err := slf.createTableSchemas(slf, i...)
+	if err != nil {
+		return err
+	}
+
 	for t, ent := range i {
 
 		ftr := reflect.TypeOf(ent)
@@ -70,14 +81,15 @@ func (slf *SQLiteFlavor) CreateTables(i ...interface{}) error {
 		}
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := slf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in slf.CreateTables")
 		}
 
 		// if the table is found to exist, skip the creation
-		// and move on to the next table in the list.
-		if slf.ExistsTable(tn) {
+		// and move on to the next table in the list.  tables
+		// dropped earlier in a plan are considered absent.
+		if !slf.absentInPlan(tn) && slf.ExistsTable(tn) {
 			if slf.log {
 				log.Printf("CreateTable - table %s exists - skipping...\n", tn)
 			}
@@ -89,34 +101,45 @@ func (slf *SQLiteFlavor) CreateTables(i ...interface{}) error {
 		slf.QsLog(tc.tblSchema)
 
 		// execute the create schema against the db
-		slf.db.MustExec(tc.tblSchema)
+		slf.mustExec(tc.tblSchema)
 		for _, sq := range tc.seq {
 			start, _ := strconv.Atoi(sq.Value)
 			slf.AlterSequenceStart(sq.Name, start-1)
 		}
-		for k, in := range tc.ind {
-			slf.CreateIndex(k, in)
+		for _, k := range indexNames(tc.ind) {
+			slf.CreateIndex(k, tc.ind[k])
 		}
 	}
-	return nil
+	return slf.createViews(slf, views...)
 }
 
 // AlterTables alters tables on the SQLite database referenced
 // by slf.DB.
 func (slf *SQLiteFlavor) AlterTables(i ...interface{}) error {
 
+	i, views := splitViews(i)
 	for t, ent := range i {
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := slf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in slf.AlterTables")
 		}
 
+		// rename the table of a model tagged with renamed_from; the
+		// renamed table cannot be examined in plan mode.
+		renamedTable, err := slf.renameTable(slf, tn, i[t])
+		if err != nil {
+			return err
+		}
+		if renamedTable && slf.planning {
+			continue
+		}
+
 		// if the table does not exist, call CreateTables
 		// if the table does exist, examine it and perform
 		// alterations if necessary
-		if !slf.ExistsTable(tn) {
+		if !renamedTable && !slf.ExistsTable(tn) {
 			slf.CreateTables(ent)
 			continue
 		}
@@ -124,13 +147,19 @@ func (slf *SQLiteFlavor) AlterTables(i ...interface{}) error {
 		// build the altered table schema and get its components
 		tc := slf.buildTablSchema(tn, i[t], true)
 
+		// rename the columns of fields tagged with renamed_from
+		renamed, err := slf.renameColumns(slf, tn, tc)
+		if err != nil {
+			return err
+		}
+
 		// go through the latest version of the model and check each
 		// field against its definition in the database.
 		var cols []string
 
 		for _, fd := range tc.flDef {
 			// new columns first
-			if !slf.ExistsColumn(tn, fd.FName) && fd.NoDB == false {
+			if !slf.ExistsColumn(tn, fd.FName) && !renamed[fd.FName] && fd.NoDB == false {
 
 				colSchema := "ALTER TABLE " + tn + " ADD COLUMN " + fd.FName + " " + fd.FType
 				for _, p := range fd.SqacPairs {
@@ -140,7 +169,7 @@ func (slf *SQLiteFlavor) AlterTables(i ...interface{}) error {
 						panic(fmt.Errorf("aborting - cannot add a primary-key (table-field %s-%s) through migration", tn, fd.FName))
 
 					case "default":
-						if fd.UnderGoType == "string" {
+						if fd.UnderGoType == "string" || fd.UnderGoType == common.ValuerType || fd.UnderGoType == common.JSONType {
 							colSchema = colSchema + " DEFAULT '" + p.Value + "'"
 						} else {
 							colSchema = colSchema + " DEFAULT " + p.Value
@@ -169,8 +198,39 @@ func (slf *SQLiteFlavor) AlterTables(i ...interface{}) error {
 			}
 		}
 
+		// bring the existing columns in line with the model and add unique and
+		// check constraints if required.  SQLite supports neither ALTER COLUMN
+		// nor ALTER TABLE ... ADD CONSTRAINT, so the table is rebuilt from the
+		// model if any of the columns differ, or if any of the model's constraints
+		// are missing.  The rebuild takes care of all of the changes, so one pass
+		// is sufficient.
+		rebuild, keep, err := slf.alterColumns(tn, tc)
+		if err != nil {
+			return err
+		}
+		for _, cn := range slf.constraintNames(tc.cons) {
+			if !slf.ExistsConstraint(tn, cn) {
+				rebuild = true
+				break
+			}
+		}
+		changed, err := slf.changedChecks(slf, tn, tc.cons)
+		if err != nil {
+			return err
+		}
+		if len(changed) > 0 {
+			rebuild = true
+		}
+		if rebuild {
+			err = slf.rebuildTable(ent, tn, keep)
+			if err != nil {
+				return err
+			}
+		}
+
 		// add indexes if required
-		for k, v := range tc.ind {
+		for _, k := range indexNames(tc.ind) {
+			v := tc.ind[k]
 			if !slf.ExistsIndex(v.TableName, k) {
 				slf.CreateIndex(k, v)
 			}
@@ -196,7 +256,7 @@ func (slf *SQLiteFlavor) AlterTables(i ...interface{}) error {
 			}
 		}
 	}
-	return nil
+	return slf.createViews(slf, views...)
 }
 
 // buildTableSchema builds a CREATE TABLE schema for the SQLite DB
@@ -209,8 +269,10 @@ func (slf *SQLiteFlavor) buildTablSchema(tn string, ent interface{}, isAlter boo
 	pKeys := ""
 	var sequences []common.SqacPair
 	indexes := make(map[string]IndexInfo)
+	constraints := make(map[string]ConstraintInfo)
+	columns := make([]ColComponents, 0)
 	fKeys := make([]FKeyInfo, 0)
-	tableSchema := "CREATE TABLE IF NOT EXISTS " + qt + tn + qt + " ("
+	tableSchema := "CREATE TABLE IF NOT EXISTS " + quotedTableName(tn, qt) + " ("
 
 	// get a list of the field names, go-types and db attributes.
 	// TagReader is a common function across db-flavors. For
@@ -309,15 +371,37 @@ func (slf *SQLiteFlavor) buildTablSchema(tn string, ent interface{}, isAlter boo
 
 		case "string":
 			col.fType = "varchar(255)"
+			if fd.TagValue("primary_key") == "uuid" {
+				col.fType = "text"
+			}
+
+		case common.DecimalType:
+			col.fType = decimalType("decimal", fd)
+
+		case common.JSONType, common.ArrayType:
+			col.fType = "text"
+
+		case common.ValuerType:
+			col.fType = "varchar(255)"
+
+		case common.DateType:
+			col.fType = "date"
+
+		case common.TimeOfDayType:
+			col.fType = "time"
 
 		case "time.Time":
 			col.fType = "datetime"
 
+		case "[]byte":
+			col.fType = "blob"
+
 		default:
 			err := fmt.Errorf("go type %s is not presently supported", fldef[idx].FType)
 			panic(err)
 		}
 		fldef[idx].FType = col.fType
+		col.uType = userType(fd, "varchar")
 
 		// read sqac tag pairs and apply
 		seqName := ""
@@ -340,10 +424,11 @@ func (slf *SQLiteFlavor) buildTablSchema(tn string, ent interface{}, isAlter boo
 					// if AUTOINCREMENT is requested on an int64/uint64, downcast
 					// the db-field-type to integer.
 					if p.Value == "inc" && strings.Contains(fd.UnderGoType, "int") {
-						if col.uType != "" {
-							log.Printf("WARNING: %s auto-incrementing primary-key field %s has user-specified db_type: %s  user-type is ignored. \n", common.GetTableName(ent), col.fName, col.uType)
-							col.uType = ""
+						// AUTOINCREMENT is only permitted on INTEGER PRIMARY KEY
+						if col.uType != "" && !strings.EqualFold(col.uType, "integer") {
+							log.Printf("WARNING: %s auto-incrementing primary-key field %s has user-specified db_type: %s  user-type is ignored. \n", slf.TableName(ent), col.fName, col.uType)
 						}
+						col.uType = ""
 						col.fPrimaryKey = "PRIMARY KEY"
 						if strings.Contains(fd.UnderGoType, "64") {
 							fldef[idx].FType = "integer"
@@ -363,7 +448,7 @@ func (slf *SQLiteFlavor) buildTablSchema(tn string, ent interface{}, isAlter boo
 					}
 
 				case "default":
-					if fd.UnderGoType == "string" {
+					if fd.UnderGoType == "string" || fd.UnderGoType == common.ValuerType || fd.UnderGoType == common.JSONType {
 						col.fDefault = "DEFAULT '" + p.Value + "'"
 					} else {
 						col.fDefault = "DEFAULT " + p.Value
@@ -401,8 +486,16 @@ func (slf *SQLiteFlavor) buildTablSchema(tn string, ent interface{}, isAlter boo
 				case "constraint":
 					if p.Value == "unique" {
 						col.fUniqueConstraint = "UNIQUE"
+					} else {
+						constraints = slf.processConstraintTag(constraints, tn, fd.FName, p.Value)
 					}
 
+				case "check":
+					constraints = slf.processCheckTag(constraints, tn, fd.FName, p.Value)
+
+				case "enum":
+					constraints = slf.processEnumTag(constraints, tn, fd.FName, p.Value)
+
 				case "index":
 					switch p.Value {
 					case "non-unique":
@@ -447,9 +540,19 @@ func (slf *SQLiteFlavor) buildTablSchema(tn string, ent interface{}, isAlter boo
 			}
 		}
 		fldef[idx].FType = col.fType
+		if col.uType != "" {
+			fldef[idx].FType = col.uType
+		}
+
+		// retain the column definition for comparison in AlterTables
+		columns = append(columns, col)
 
 		// add the current column to the schema
-		tableSchema = tableSchema + qt + col.fName + qt + " " + col.fType
+		if col.uType != "" {
+			tableSchema = tableSchema + qt + col.fName + qt + " " + col.uType
+		} else {
+			tableSchema = tableSchema + qt + col.fName + qt + " " + col.fType
+		}
 		if col.fPrimaryKey != "" {
 			tableSchema = tableSchema + " " + col.fPrimaryKey
 		}
@@ -468,6 +571,11 @@ func (slf *SQLiteFlavor) buildTablSchema(tn string, ent interface{}, isAlter boo
 		tableSchema = tableSchema + ", "
 	}
 
+	// add the table-level unique and check constraints
+	for _, cn := range slf.constraintNames(constraints) {
+		tableSchema = tableSchema + slf.constraintClause(cn, constraints[cn]) + ", "
+	}
+
 	if tableSchema != "" && pKeys == "" {
 		tableSchema = strings.TrimSpace(tableSchema)
 		tableSchema = strings.TrimSuffix(tableSchema, ",")
@@ -497,7 +605,7 @@ func (slf *SQLiteFlavor) buildTablSchema(tn string, ent interface{}, isAlter boo
 					log.Printf("WARNING: unable to determine foreign-key-name based on %v.  SKIPPING.", v)
 					continue
 				}
-				tableSchema = tableSchema + " CONSTRAINT " + fkn + " FOREIGN KEY (" + v.FromField + ") REFERENCES " + v.RefTable + "(" + v.RefField + "),"
+				tableSchema = tableSchema + " CONSTRAINT " + fkn + " FOREIGN KEY (" + v.FromField + ") REFERENCES " + bareTableName(v.RefTable) + "(" + v.RefField + "),"
 			}
 		}
 	}
@@ -514,6 +622,8 @@ func (slf *SQLiteFlavor) buildTablSchema(tn string, ent interface{}, isAlter boo
 		flDef:     fldef,
 		seq:       sequences,
 		ind:       indexes,
+		cons:      constraints,
+		cols:      columns,
 		pk:        pKeys,
 		err:       err,
 	}
@@ -532,11 +642,18 @@ func (slf *SQLiteFlavor) buildTablSchema(tn string, ent interface{}, isAlter boo
 // the provided list of go struct definitions.
 func (slf *SQLiteFlavor) DropTables(i ...interface{}) error {
 
+	// views are dropped ahead of the tables they select from
+	i, views := splitViews(i)
+	err := slf.dropViews(slf, views...)
+	if err != nil {
+		return err
+	}
+
 	dropSchema := ""
 	for t := range i {
 
 		// determine the table name
-		tn := common.GetTableName(i[t])
+		tn := slf.TableName(i[t])
 		if tn == "" {
 			return fmt.Errorf("unable to determine table name in slf.DropTables")
 		}
@@ -548,8 +665,9 @@ func (slf *SQLiteFlavor) DropTables(i ...interface{}) error {
 			if slf.log {
 				log.Printf("table %s exists - adding to drop schema...\n", tn)
 			}
-			dropSchema = dropSchema + "DROP TABLE IF EXISTS " + tn + ";"
+			dropSchema = dropSchema + slf.dropTableSQL(tn)
 			slf.ProcessSchema(dropSchema)
+			slf.dropInPlan(tn)
 			dropSchema = ""
 		}
 	}
@@ -573,11 +691,160 @@ func (slf *SQLiteFlavor) DestructiveResetTables(i ...interface{}) error {
 	return nil
 }
 
+// PlanCreateTables returns the ordered list of statements that CreateTables
+// would execute on the connected SQLite db, without making any changes.
+func (slf *SQLiteFlavor) PlanCreateTables(i ...interface{}) ([]string, error) {
+	return slf.runPlan(func() error { return slf.CreateTables(i...) })
+}
+
+// PlanAlterTables returns the ordered list of statements that AlterTables
+// would execute on the connected SQLite db, without making any changes.
+func (slf *SQLiteFlavor) PlanAlterTables(i ...interface{}) ([]string, error) {
+	return slf.runPlan(func() error { return slf.AlterTables(i...) })
+}
+
+// PlanDropTables returns the ordered list of statements that DropTables
+// would execute on the connected SQLite db, without making any changes.
+func (slf *SQLiteFlavor) PlanDropTables(i ...interface{}) ([]string, error) {
+	return slf.runPlan(func() error { return slf.DropTables(i...) })
+}
+
+// PlanDestructiveResetTables returns the ordered list of statements that
+// DestructiveResetTables would execute on the connected SQLite db, without
+// making any changes.
+func (slf *SQLiteFlavor) PlanDestructiveResetTables(i ...interface{}) ([]string, error) {
+	return slf.runPlan(func() error { return slf.DestructiveResetTables(i...) })
+}
+
+// SnapshotTables returns a snapshot of the SQLite tables described by
+// the models.
+func (slf *SQLiteFlavor) SnapshotTables(i ...interface{}) (Snapshot, error) {
+	return slf.snapshotTables(slf, i...)
+}
+
+// PlanMigration returns the statements required to migrate the SQLite
+// tables from their state in the connected db, or in snapshot from, to
+// the models, along with the statements required to revert the migration.
+// Column changes are carried out by rebuilding the table, as SQLite does
+// not support ALTER COLUMN.
+func (slf *SQLiteFlavor) PlanMigration(from *Snapshot, i ...interface{}) ([]string, []string, error) {
+	return slf.planMigration(slf, from, i...)
+}
+
+// GenerateModels reads the named SQLite tables, or all tables if no names
+// are provided, and returns the source of a go file in package pkg holding
+// a sqac-tagged model for each of them.
+func (slf *SQLiteFlavor) GenerateModels(pkg string, tn ...string) ([]byte, error) {
+	return slf.generateModels(slf, pkg, tn...)
+}
+
+// DescribeTable reads the columns, primary-key, indexes, foreign-keys and
+// sequences of SQLite table tn.
+func (slf *SQLiteFlavor) DescribeTable(tn string) (TableInfo, error) {
+	return slf.describeTable(slf, tn)
+}
+
+// tableComponents returns the components of the SQLite table described
+// by model ent.
+func (slf *SQLiteFlavor) tableComponents(tn string, ent interface{}) TblComponents {
+	return slf.buildTablSchema(tn, ent, false)
+}
+
+// dropTableSQL returns the statement used to drop table tn.
+func (slf *SQLiteFlavor) dropTableSQL(tn string) string {
+	return "DROP TABLE IF EXISTS " + tn + ";"
+}
+
+// dropIndexSQL returns the statement used to drop index in.  SQLite
+// indexes live in the schema of their table.
+func (slf *SQLiteFlavor) dropIndexSQL(tn string, in string) string {
+	return "DROP INDEX IF EXISTS " + qualifiedName(tn, in) + ";"
+}
+
+// addColumnSQL returns the statement used to add column ci to table tn.
+// SQLite requires a default value for NOT NULL columns added to an
+// existing table.
+func (slf *SQLiteFlavor) addColumnSQL(tn string, ci ColumnInfo) string {
+
+	qt := slf.GetDBQuote()
+	colSchema := "ALTER TABLE " + quotedTableName(tn, qt) + " ADD COLUMN " + qt + ci.Name + qt + " " + ci.Type
+	if !ci.Nullable {
+		colSchema = colSchema + " NOT NULL"
+	}
+	if ci.Default != "" {
+		colSchema = colSchema + " DEFAULT " + ci.Default
+	}
+	return colSchema + ";"
+}
+
+// ForTenant returns a handle sharing the connection to the SQLite database, whose
+// table names are routed to the tables of tenant via the tenant resolver.
+func (slf *SQLiteFlavor) ForTenant(tenant string) PublicDB {
+	t := *slf
+	t.tenant = tenant
+	return &t
+}
+
+// CreateSchema is not supported for SQLite, where schemas are attached
+// database files.  Attach the database file via ATTACH DATABASE on a
+// handle limited to a single connection instead.
+func (slf *SQLiteFlavor) CreateSchema(sn string) error {
+	return slf.createSchema(slf, sn)
+}
+
+// DropSchema is not supported for SQLite; detach the database file via
+// DETACH DATABASE instead.
+func (slf *SQLiteFlavor) DropSchema(sn string) error {
+	return slf.dropSchema(slf, sn)
+}
+
+// ExistsSchema checks whether sn is the name of a database attached to
+// the connected SQLite database (main for the database file itself).
+func (slf *SQLiteFlavor) ExistsSchema(sn string) bool {
+
+	n := 0
+	qs := "SELECT COUNT(*) FROM pragma_database_list WHERE name = ?;"
+	slf.QsLog(qs, sn)
+	err := slf.db.QueryRow(qs, sn).Scan(&n)
+	if err != nil {
+		return false
+	}
+	return n > 0
+}
+
+// createSchemaSQL reports that SQLite schemas cannot be created.
+func (slf *SQLiteFlavor) createSchemaSQL(sn string) (string, error) {
+	return "", fmt.Errorf("sqlite schema %s does not exist - attach the database file as %s instead", sn, sn)
+}
+
+// dropSchemaSQL reports that SQLite schemas cannot be dropped.
+func (slf *SQLiteFlavor) dropSchemaSQL(sn string) (string, error) {
+	return "", fmt.Errorf("sqlite schema %s cannot be dropped - detach the database file instead", sn)
+}
+
+// sqliteSchema splits table name tn into its schema and table name, with
+// the schema defaulting to main.
+func sqliteSchema(tn string) (string, string) {
+
+	sn, n := splitTableName(tn)
+	if sn == "" {
+		sn = "main"
+	}
+	return sn, n
+}
+
+// sqliteMaster returns the sqlite_master table of the schema of table tn,
+// along with the bare table name.
+func sqliteMaster(tn string) (string, string) {
+	return qualifiedName(tn, "sqlite_master"), bareTableName(tn)
+}
+
 // ExistsTable checks that the specified table exists in the SQLite database file.
 func (slf *SQLiteFlavor) ExistsTable(tn string) bool {
 
 	n := 0
-	reqQuery := "SELECT COUNT(*) FROM sqlite_master WHERE type=\"table\" AND name=\"" + tn + "\";"
+	master, tn := sqliteMaster(tn)
+	reqQuery := "SELECT COUNT(*) FROM " + master + " WHERE type=\"table\" AND name=\"" + tn + "\";"
 	slf.QsLog(reqQuery)
 	err := slf.db.QueryRow(reqQuery).Scan(&n)
 	if err != nil {
@@ -589,12 +856,53 @@ func (slf *SQLiteFlavor) ExistsTable(tn string) bool {
 	return true
 }
 
+// ExistsView checks that the specified view exists in the SQLite database file.
+func (slf *SQLiteFlavor) ExistsView(vn string) bool {
+
+	n := 0
+	master, vn := sqliteMaster(vn)
+	qs := "SELECT COUNT(*) FROM " + master + " WHERE type = 'view' AND name = ?;"
+	slf.QsLog(qs, vn)
+	slf.db.QueryRow(qs, vn).Scan(&n)
+	return n > 0
+}
+
+// DropView drops view vn on the SQLite database if it exists.
+func (slf *SQLiteFlavor) DropView(vn string) error {
+	return slf.dropView(slf, vn)
+}
+
+// createViewSQL returns the statements replacing view vn.  SQLite has no
+// CREATE OR REPLACE VIEW, so the view is dropped and created again.
+func (slf *SQLiteFlavor) createViewSQL(vn, query string) []string {
+	return []string{"DROP VIEW IF EXISTS " + vn + ";", "CREATE VIEW " + vn + " AS " + query + ";"}
+}
+
+// CreateIndex creates the index contained in the incoming IndexInfo
+// structure.  SQLite places an index in the schema of its table, and
+// requires the table name of the index to be unqualified.
+func (slf *SQLiteFlavor) CreateIndex(in string, index IndexInfo) error {
+
+	if len(index.IndexFields) == 1 {
+		in = "idx_" + bareTableName(index.TableName) + "_" + index.IndexFields[0]
+	}
+
+	indexSchema := "CREATE INDEX "
+	if index.Unique {
+		indexSchema = "CREATE UNIQUE INDEX "
+	}
+	indexSchema = indexSchema + qualifiedName(index.TableName, in) + " ON " + bareTableName(index.TableName) + " (" + strings.Join(index.IndexFields, ", ") + ");"
+
+	slf.ProcessSchema(indexSchema)
+	return nil
+}
+
 // DropIndex drops the specfied index on the connected SQLite database.  SQLite does
 // not require the table name to drop an index, but it is provided in order to
 // comply with the PublicDB interface definition.
 func (slf *SQLiteFlavor) DropIndex(tn string, in string) error {
 
-	indexSchema := "DROP INDEX IF EXISTS " + in + ";"
+	indexSchema := slf.dropIndexSQL(tn, in)
 	slf.ProcessSchema(indexSchema)
 	return nil
 }
@@ -605,7 +913,8 @@ func (slf *SQLiteFlavor) DropIndex(tn string, in string) error {
 func (slf *SQLiteFlavor) ExistsIndex(tn string, in string) bool {
 
 	n := 0
-	indQuery := "SELECT COUNT(*) FROM sqlite_master WHERE \"type\" = \"index\" AND \"name\" = \"" + in + "\";"
+	master, _ := sqliteMaster(tn)
+	indQuery := "SELECT COUNT(*) FROM " + master + " WHERE \"type\" = \"index\" AND \"name\" = \"" + in + "\";"
 	slf.QsLog(indQuery)
 
 	slf.db.QueryRow(indQuery).Scan(&n)
@@ -627,7 +936,8 @@ func (slf *SQLiteFlavor) ExistsColumn(tn string, cn string) bool {
 		// without using the built-in PRAGMA, we have to rely on the table creation SQL
 		// that is stored in the sqlite_master table.
 		sqlString := ""
-		colQuery := "SELECT \"sql\" FROM sqlite_master WHERE \"type\" = \"table\" AND \"name\" = \"" + tn + "\""
+		master, bn := sqliteMaster(tn)
+		colQuery := "SELECT \"sql\" FROM " + master + " WHERE \"type\" = \"table\" AND \"name\" = \"" + bn + "\""
 		slf.QsLog(colQuery)
 
 		slf.db.QueryRow(colQuery).Scan(&sqlString)
@@ -650,7 +960,8 @@ func (slf *SQLiteFlavor) ExistsColumn(tn string, cn string) bool {
 // records.
 func (slf *SQLiteFlavor) AlterSequenceStart(name string, start int) error {
 
-	asQuery := "UPDATE sqlite_sequence SET seq = " + strconv.Itoa(start) + " WHERE name = '" + name + "';"
+	seqTn, name := qualifiedName(name, "sqlite_sequence"), bareTableName(name)
+	asQuery := "UPDATE " + seqTn + " SET seq = " + strconv.Itoa(start) + " WHERE name = '" + name + "';"
 	slf.QsLog(asQuery)
 
 	result, err := slf.Exec(asQuery)
@@ -662,7 +973,7 @@ func (slf *SQLiteFlavor) AlterSequenceStart(name string, start int) error {
 		}
 	}
 
-	asQuery = "INSERT INTO sqlite_sequence (name,seq) VALUES ('" + name + "', " + strconv.Itoa(start) + ");"
+	asQuery = "INSERT INTO " + seqTn + " (name,seq) VALUES ('" + name + "', " + strconv.Itoa(start) + ");"
 	slf.QsLog(asQuery)
 
 	result, err = slf.Exec(asQuery)
@@ -687,7 +998,7 @@ func (slf *SQLiteFlavor) GetNextSequenceValue(name string) (int, error) {
 		// colQuery := fmt.Sprintf("PRAGMA table_info(\"%s\")", tn)  // does not work - annoying
 		// without using the built-in PRAGMA, we have to rely on the table creation SQL
 		// that is stored in the sqlite_master table - not very exact.
-		seqQuery := "SELECT \"seq\" FROM sqlite_sequence WHERE \"name\" = '" + name + "'"
+		seqQuery := "SELECT \"seq\" FROM " + qualifiedName(name, "sqlite_sequence") + " WHERE \"name\" = '" + bareTableName(name) + "'"
 		slf.QsLog(seqQuery)
 
 		err := slf.db.QueryRow(seqQuery).Scan(&seq)
@@ -716,17 +1027,17 @@ func (slf *SQLiteFlavor) CreateForeignKey(i interface{}, ft, rt, ff, rf string)
 	cmds := make([]string, 0)
 
 	// confirm the table name
-	tn := common.GetTableName(i)
+	tn := slf.TableName(i)
 	if tn == "" || tn != ft {
 		return fmt.Errorf("unable to confirm table name in slf.CreateForeignKey")
 	}
 
 	// if the table is found to exist, copy it to a temp backup table - Sprintf for legibility
 	if slf.ExistsTable(tn) {
-		bakTn = fmt.Sprintf("_%s_bak", ft)
+		bakTn = qualifiedName(ft, fmt.Sprintf("_%s_bak", bareTableName(ft)))
 		q = "DROP TABLE IF EXISTS " + bakTn + ";"
 		cmds = append(cmds, q)
-		q = fmt.Sprintf("ALTER TABLE %s RENAME TO _%s_bak;", ft, ft)
+		q = fmt.Sprintf("ALTER TABLE %s RENAME TO _%s_bak;", ft, bareTableName(ft))
 		cmds = append(cmds, q)
 		q = "DROP TABLE IF EXISTS " + tn + ";"
 		cmds = append(cmds, q)
@@ -739,7 +1050,7 @@ func (slf *SQLiteFlavor) CreateForeignKey(i interface{}, ft, rt, ff, rf string)
 	}
 
 	// build the new foreign-key constraint clause
-	fkc := fmt.Sprintf(" CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(%s)", fkn, ff, rt, rf)
+	fkc := fmt.Sprintf(" CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(%s)", fkn, ff, bareTableName(rt), rf)
 
 	// build the new table schema with foreign-key constraint
 	tc := slf.buildTablSchema(tn, i, false)
@@ -789,6 +1100,375 @@ func (slf *SQLiteFlavor) CreateForeignKey(i interface{}, ft, rt, ff, rf string)
 	return nil
 }
 
+// CreateConstraint is not supported directly for SQLite, as the addition of a
+// constraint to an existing table requires the table to be rebuilt from its
+// model.  Add the constraint to the model's sqac tags and call AlterTables.
+func (slf *SQLiteFlavor) CreateConstraint(cn string, constraint ConstraintInfo) error {
+
+	return fmt.Errorf("sqlite does not support adding constraint %s to an existing table - add the constraint to the model and call AlterTables", cn)
+}
+
+// DropConstraint is not supported directly for SQLite, as the removal of a
+// constraint from an existing table requires the table to be rebuilt from
+// its model.  Remove the constraint from the model's sqac tags and call
+// AlterTables.
+func (slf *SQLiteFlavor) DropConstraint(tn string, cn string) error {
+
+	return fmt.Errorf("sqlite does not support dropping constraint %s from an existing table - remove the constraint from the model and call AlterTables", cn)
+}
+
+// ExistsConstraint checks the create schema of table tn in the SQLite database
+// file for the named unique or check constraint.
+func (slf *SQLiteFlavor) ExistsConstraint(tn string, cn string) bool {
+
+	var count uint64
+	master, bn := sqliteMaster(tn)
+	conQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE type='table' AND tbl_name='%s' AND sql LIKE '%%CONSTRAINT %s %%';", master, bn, cn)
+	slf.QsLog(conQuery)
+
+	err := slf.Get(&count, conQuery)
+	if err != nil {
+		return false
+	}
+
+	if count > 0 {
+		return true
+	}
+	return false
+}
+
+// readColumns reads the column definitions of table tn from the
+// SQLite database file.
+func (slf *SQLiteFlavor) readColumns(tn string) ([]ColumnInfo, error) {
+
+	qs := "PRAGMA " + qualifiedName(tn, "table_info") + "('" + bareTableName(tn) + "');"
+	slf.QsLog(qs)
+
+	rows, err := slf.db.Query(qs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// the rowid alias of a table declared with AUTOINCREMENT draws its
+	// values from sqlite_sequence.
+	autoInc := strings.Contains(strings.ToUpper(slf.readCreateSchema(tn)), "AUTOINCREMENT")
+
+	cols := make([]ColumnInfo, 0)
+	for rows.Next() {
+		var ci ColumnInfo
+		var cid, notNull, pk int
+		var dflt sql.NullString
+		err = rows.Scan(&cid, &ci.Name, &ci.Type, &notNull, &dflt, &pk)
+		if err != nil {
+			return nil, err
+		}
+		ci.Nullable = notNull == 0
+		ci.Default = dflt.String
+		ci.PrimaryKey = pk > 0
+		ci.Identity = autoInc && ci.PrimaryKey && strings.EqualFold(ci.Type, "integer")
+		cols = append(cols, ci)
+	}
+	return slf.planColumns(tn, cols), rows.Err()
+}
+
+// normalizeColumn maps the SQLite default expression formats onto a
+// common representation for column comparison.  SQLite reports the
+// declared column type, so no type mapping is required.
+func (slf *SQLiteFlavor) normalizeColumn(ci ColumnInfo) ColumnInfo {
+
+	ci.Type = strings.ToLower(strings.TrimSpace(ci.Type))
+
+	d := trimDefault(ci.Default)
+	switch strings.ToLower(d) {
+	case "true":
+		d = "1"
+	case "false":
+		d = "0"
+	}
+	ci.Default = d
+	return ci
+}
+
+// readTableNames reads the names of the tables in the schema set via
+// SetSchema, or the connected SQLite database.  The internal sqlite_
+// tables are not included.
+func (slf *SQLiteFlavor) readTableNames() ([]string, error) {
+
+	master := "sqlite_master"
+	if slf.schema != "" {
+		master = slf.schema + "." + master
+	}
+	qs := "SELECT name FROM " + master + " WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name;"
+	slf.QsLog(qs)
+
+	tns := make([]string, 0)
+	err := slf.db.Select(&tns, qs)
+	if err != nil {
+		return nil, err
+	}
+	return tns, nil
+}
+
+// readIndexes reads the indexes of table tn from the connected SQLite
+// database.  Only indexes created via CREATE INDEX are included.
+func (slf *SQLiteFlavor) readIndexes(tn string) (map[string]IndexInfo, error) {
+
+	qs := "SELECT l.name, l.\"unique\", i.name FROM pragma_index_list(?, ?) l, pragma_index_info(l.name, ?) i " +
+		"WHERE l.origin = 'c' ORDER BY l.name, i.seqno;"
+	sn, bn := sqliteSchema(tn)
+	slf.QsLog(qs, bn, sn, sn)
+
+	rows, err := slf.db.Query(qs, bn, sn, sn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ind := make(map[string]IndexInfo)
+	for rows.Next() {
+		var in, cn string
+		var unique int
+		err = rows.Scan(&in, &unique, &cn)
+		if err != nil {
+			return nil, err
+		}
+		addIndexField(ind, tn, in, unique == 1, cn)
+	}
+	return ind, rows.Err()
+}
+
+// readForeignKeys reads the foreign-keys of table tn from the connected
+// SQLite database.  SQLite does not record the names of foreign-keys, so
+// the sqac naming convention is applied.
+func (slf *SQLiteFlavor) readForeignKeys(tn string) ([]FKeyInfo, error) {
+
+	qs := "SELECT \"from\", \"table\", \"to\" FROM pragma_foreign_key_list(?, ?) ORDER BY id, seq;"
+	sn, bn := sqliteSchema(tn)
+	slf.QsLog(qs, bn, sn)
+
+	rows, err := slf.db.Query(qs, bn, sn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fks := make([]FKeyInfo, 0)
+	for rows.Next() {
+		fk := FKeyInfo{FromTable: tn}
+		err = rows.Scan(&fk.FromField, &fk.RefTable, &fk.RefField)
+		if err != nil {
+			return nil, err
+		}
+		fk.FKeyName = "fk_" + bn + "_" + fk.RefTable + "_" + fk.RefField
+		fks = append(fks, fk)
+	}
+	return fks, rows.Err()
+}
+
+// sqliteCheckRegexp matches the named check constraints of a CREATE TABLE
+// statement; CONSTRAINT ck_dock_status_enum CHECK (status IN ('a', 'b'))
+var sqliteCheckRegexp = regexp.MustCompile(`CONSTRAINT "?(\w+)"? CHECK \(((?:[^()']|'(?:[^']|'')*'|\([^()]*\))*)\)`)
+
+// readChecks reads the names and definitions of the check constraints
+// of table tn from the CREATE TABLE statement held in sqlite_master.
+func (slf *SQLiteFlavor) readChecks(tn string) (map[string]string, error) {
+
+	master, bn := sqliteMaster(tn)
+	qs := "SELECT sql FROM " + master + " WHERE type = 'table' AND name = ?;"
+	slf.QsLog(qs, bn)
+
+	ts := ""
+	err := slf.db.QueryRow(qs, bn).Scan(&ts)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make(map[string]string)
+	for _, m := range sqliteCheckRegexp.FindAllStringSubmatch(ts, -1) {
+		checks[m[1]] = m[2]
+	}
+	return checks, nil
+}
+
+// renameColumnSQL returns the statement renaming column from of table tn
+// to column to.  SQLite supports RENAME COLUMN as of version 3.25, so
+// there is no need to rebuild the table.
+func (slf *SQLiteFlavor) renameColumnSQL(tn, from, to string) string {
+	return "ALTER TABLE " + tn + " RENAME COLUMN " + from + " TO " + to + ";"
+}
+
+// renameTableSQL returns the statements renaming table from to table to.
+// SQLite carries the AUTOINCREMENT counter of the table along.  Tables
+// cannot be moved between schemas.
+func (slf *SQLiteFlavor) renameTableSQL(from, to string) ([]string, error) {
+	return []string{"ALTER TABLE " + from + " RENAME TO " + bareTableName(to) + ";"}, nil
+}
+
+// alterColumns compares the existing columns of table tn with the model
+// captured in tc.  SQLite does not support ALTER COLUMN, so rather than
+// issuing statements, alterColumns reports whether the table needs to be
+// rebuilt, along with the list of columns that are no longer part of the
+// model, but must be retained as per the destructive-change policy.
+func (slf *SQLiteFlavor) alterColumns(tn string, tc TblComponents) (bool, []ColumnInfo, error) {
+
+	dbCols, err := slf.readColumns(tn)
+	if err != nil {
+		return false, nil, err
+	}
+
+	deltas := slf.diffColumns(slf.modelColumns(tc), dbCols, slf.normalizeColumn)
+	deltas, err = slf.applyDestructivePolicy(tn, deltas)
+	if err != nil {
+		return false, nil, err
+	}
+
+	inModel := make(map[string]bool)
+	for _, c := range tc.cols {
+		inModel[strings.ToLower(c.fName)] = true
+	}
+	dropped := make(map[string]bool)
+	for _, d := range deltas {
+		if d.op == "DROP" {
+			dropped[strings.ToLower(d.name)] = true
+		}
+	}
+
+	keep := make([]ColumnInfo, 0)
+	for _, c := range dbCols {
+		if !inModel[strings.ToLower(c.Name)] && !dropped[strings.ToLower(c.Name)] {
+			keep = append(keep, c)
+		}
+	}
+	return len(deltas) > 0, keep, nil
+}
+
+// rebuildTable recreates table tn from the sqac model (i) using the copy, drop,
+// recreate, reload cycle required by SQLite to make changes to column definitions
+// and table-level constraints.  The model columns found in the existing table are
+// copied back into the rebuilt table, along with the retained columns in keep,
+// which are added to the rebuilt table as nullable columns.  The auto-increment
+// position of the table is carried over.  Foreign-key constraints are temporarily
+// disabled on the db for the duration of the transaction processing.
+func (slf *SQLiteFlavor) rebuildTable(i interface{}, tn string, keep []ColumnInfo) error {
+
+	dbCols, err := slf.readColumns(tn)
+	if err != nil {
+		return err
+	}
+
+	// build the new table schema from the model
+	tc := slf.buildTablSchema(tn, i, false)
+	from := TableSnapshot{Name: tn, Columns: dbCols}
+	to := TableSnapshot{Name: tn, Create: tc.tblSchema, Columns: slf.modelColumns(tc)}
+	cmds := slf.rebuildTableSQL(tn, from, to, keep)
+
+	// disable foreign-key checks to start the transaction processing
+	qs := "PRAGMA foreign_keys=off;"
+	slf.QsLog(qs)
+	_, err = slf.Exec(qs)
+	if err != nil {
+		return err
+	}
+
+	// submit the transaction buffer
+	err = slf.ProcessTransaction(cmds)
+	if err != nil {
+		// attempt to reactivate foreign-key constraints
+		_, fkErr := slf.Exec("PRAGMA foreign_keys=on;")
+		if fkErr != nil {
+			log.Println("WARNING: FOREIGN KEY CONSTRAINTS ARE PRESENTLY DEACATIVATED!")
+		}
+		return err
+	}
+
+	// reactivate foreign-key constraints
+	qs = "PRAGMA foreign_keys=on;"
+	slf.QsLog(qs)
+	_, err = slf.Exec(qs)
+	if err != nil {
+		log.Println("WARNING: FOREIGN KEY CONSTRAINTS MAY PRESENTLY BE DEACATIVATED!")
+		return err
+	}
+	return nil
+}
+
+// rebuildTableSQL returns the statements used to rebuild table tn with the
+// to.Create schema.  The columns of to that are found in from are copied
+// into the rebuilt table, and the columns in keep are added to the rebuilt
+// table as nullable columns and copied.  Columns that have become NOT NULL
+// are filled with their default value where one has been specified.
+func (slf *SQLiteFlavor) rebuildTableSQL(tn string, from, to TableSnapshot, keep []ColumnInfo) []string {
+
+	qt := slf.GetDBQuote()
+	bakTn := qualifiedName(tn, "_"+bareTableName(tn)+"_bak")
+	seqTn := qualifiedName(tn, "sqlite_sequence")
+	bn := bareTableName(tn)
+	cmds := make([]string, 0)
+
+	exists := make(map[string]bool)
+	for _, c := range from.Columns {
+		exists[strings.ToLower(c.Name)] = true
+	}
+
+	cols := ""
+	vals := ""
+	for _, c := range to.Columns {
+		if !exists[strings.ToLower(c.Name)] {
+			continue
+		}
+		cols = cols + qt + c.Name + qt + ", "
+
+		// fill NULLs in columns that have become NOT NULL with the default
+		if !c.Nullable && c.Default != "" {
+			vals = vals + "COALESCE(" + qt + c.Name + qt + ", " + c.Default + "), "
+		} else {
+			vals = vals + qt + c.Name + qt + ", "
+		}
+	}
+
+	cmds = append(cmds, "DROP TABLE IF EXISTS "+bakTn+";")
+	cmds = append(cmds, "ALTER TABLE "+quotedTableName(tn, qt)+" RENAME TO "+bareTableName(bakTn)+";")
+	cmds = append(cmds, to.Create)
+	for _, c := range keep {
+		colSchema := "ALTER TABLE " + quotedTableName(tn, qt) + " ADD COLUMN " + qt + c.Name + qt + " " + c.Type
+		if c.Default != "" {
+			colSchema = colSchema + " DEFAULT " + c.Default
+		}
+		cmds = append(cmds, colSchema+";")
+		cols = cols + qt + c.Name + qt + ", "
+		vals = vals + qt + c.Name + qt + ", "
+	}
+	cols = strings.TrimSuffix(cols, ", ")
+	vals = strings.TrimSuffix(vals, ", ")
+	cmds = append(cmds, "INSERT INTO "+quotedTableName(tn, qt)+" ("+cols+") SELECT "+vals+" FROM "+bakTn+";")
+
+	// carry the auto-increment position over to the rebuilt table
+	if strings.Contains(to.Create, "AUTOINCREMENT") {
+		bakBn := bareTableName(bakTn)
+		cmds = append(cmds, "UPDATE "+seqTn+" SET seq = (SELECT MAX(seq) FROM "+seqTn+" WHERE name IN ('"+bn+"', '"+bakBn+"')) WHERE name = '"+bn+"';")
+		cmds = append(cmds, "INSERT INTO "+seqTn+" (name, seq) SELECT '"+bn+"', seq FROM "+seqTn+" WHERE name = '"+bakBn+"' "+
+			"AND NOT EXISTS (SELECT 1 FROM "+seqTn+" WHERE name = '"+bn+"');")
+	}
+	cmds = append(cmds, "DROP TABLE IF EXISTS "+bakTn+";")
+	return cmds
+}
+
+// readCreateSchema returns the CREATE TABLE statement of table tn as
+// recorded in sqlite_master.
+func (slf *SQLiteFlavor) readCreateSchema(tn string) string {
+
+	schema := ""
+	master, bn := sqliteMaster(tn)
+	qs := "SELECT sql FROM " + master + " WHERE type = 'table' AND name = ?;"
+	slf.QsLog(qs, bn)
+	err := slf.db.QueryRow(qs, bn).Scan(&schema)
+	if err != nil {
+		return ""
+	}
+	return schema
+}
+
 // DropForeignKey drops a foreign-key on an existing column.  Since SQLite does not
 // support the addition or deletion of foreign-key relationships on existing tables,
 // the existing table is copied to a backup table, dropped and then recreated using
@@ -804,17 +1484,17 @@ func (slf *SQLiteFlavor) DropForeignKey(i interface{}, ft, fkn string) error {
 	cmds := make([]string, 0)
 
 	// confirm the table name
-	tn := common.GetTableName(i)
+	tn := slf.TableName(i)
 	if tn == "" || tn != ft {
 		return fmt.Errorf("unable to confirm table name in slf.DropForeignKey")
 	}
 
 	// if the table is found to exist, copy it to a temp backup table
 	if slf.ExistsTable(tn) {
-		bakTn = fmt.Sprintf("_%s_bak", ft)
+		bakTn = qualifiedName(ft, fmt.Sprintf("_%s_bak", bareTableName(ft)))
 		q = "DROP TABLE IF EXISTS " + bakTn + ";"
 		cmds = append(cmds, q)
-		q = fmt.Sprintf("ALTER TABLE %s RENAME TO _%s_bak;", ft, ft)
+		q = fmt.Sprintf("ALTER TABLE %s RENAME TO _%s_bak;", ft, bareTableName(ft))
 		cmds = append(cmds, q)
 		q = "DROP TABLE IF EXISTS " + tn + ";"
 		cmds = append(cmds, q)
@@ -869,9 +1549,9 @@ func (slf *SQLiteFlavor) DropForeignKey(i interface{}, ft, fkn string) error {
 func (slf *SQLiteFlavor) ExistsForeignKeyByName(i interface{}, fkn string) (bool, error) {
 
 	var count uint64
-	tn := common.GetTableName(i)
+	master, tn := sqliteMaster(slf.TableName(i))
 
-	fkQuery := fmt.Sprintf("SELECT COUNT(*) FROM sqlite_master WHERE tbl_name='%s' AND sql like'%%%s%%';", tn, fkn)
+	fkQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE tbl_name='%s' AND sql like'%%%s%%';", master, tn, fkn)
 	slf.QsLog(fkQuery)
 
 	err := slf.Get(&count, fkQuery)
@@ -896,6 +1576,12 @@ func (slf *SQLiteFlavor) ExistsForeignKeyByFields(i interface{}, ft, rt, ff, rf
 	return slf.ExistsForeignKeyByName(i, fkn)
 }
 
+// CallProcedure is not supported for SQLite, which has no stored
+// procedures.
+func (slf *SQLiteFlavor) CallProcedure(name string, in interface{}, out interface{}) error {
+	return fmt.Errorf("stored procedures are not supported by SQLite - unable to call %s", name)
+}
+
 //================================================================
 // CRUD ops
 //================================================================
@@ -916,7 +1602,8 @@ func (slf *SQLiteFlavor) Create(ent interface{}) error {
 	// build the sqlite insert query
 	insFlds := ""
 	insVals := ""
-	for k, v := range info.fldMap {
+	for _, k := range info.fields() {
+		v := info.fldMap[k]
 		if v == "DEFAULT" {
 			continue
 		}
@@ -928,30 +1615,34 @@ func (slf *SQLiteFlavor) Create(ent interface{}) error {
 
 	// build the sqlite insert query
 	insQuery := "INSERT OR FAIL INTO " + info.tn + " (" + insFlds + ") VALUES (" + insVals + ");"
-	slf.QsLog(insQuery)
+	slf.QsLog(insQuery, info.args...)
 
 	// clear the source data - deals with non-persistet columns
 	e := reflect.ValueOf(info.ent).Elem()
 	e.Set(reflect.Zero(e.Type()))
 
 	// attempt the insert and read the result back into info.resultMap
-	result, err := slf.db.Exec(insQuery)
+	result, err := slf.db.Exec(insQuery, info.args...)
 	if err != nil {
 		return err
 	}
 
-	lastID, err := result.LastInsertId()
-	if err != nil {
-		return err
+	var lastID int64
+	if info.incKeyName != "" {
+		lastID, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
 	}
 
-	selQuery := "SELECT * FROM " + info.tn + " WHERE " + info.incKeyName + " = " + strconv.FormatInt(lastID, 10) + " LIMIT 1;"
+	selQuery := "SELECT * FROM " + info.tn + " WHERE " + info.keyCondition(lastID) + " LIMIT 1;"
 	slf.QsLog(selQuery)
 
-	err = slf.db.QueryRowx(selQuery).StructScan(info.ent) //.MapScan(info.resultMap) // SliceScan
+	err = slf.structScan(slf.db.QueryRowx(selQuery), info.ent) //.MapScan(info.resultMap) // SliceScan
 	if err != nil {
 		return err
 	}
+	slf.readTimes(info.ent)
 	info.entValue = reflect.ValueOf(info.ent)
 	return nil
 }
@@ -988,20 +1679,21 @@ func (slf *SQLiteFlavor) Update(ent interface{}) error {
 	keyList = strings.TrimSuffix(keyList, " AND")
 
 	colList := ""
-	for k, v := range info.fldMap {
+	for _, k := range info.fields() {
+		v := info.fldMap[k]
 		colList = fmt.Sprintf("%s %s = %s, ", colList, k, v)
 	}
 	colList = strings.TrimSuffix(colList, ", ")
 
 	updQuery := "UPDATE OR FAIL " + info.tn + " SET " + colList + " WHERE " + keyList + ";"
-	slf.QsLog(updQuery)
+	slf.QsLog(updQuery, info.args...)
 
 	// clear the source data - deals with non-persistent columns
 	e := reflect.ValueOf(info.ent).Elem()
 	e.Set(reflect.Zero(e.Type()))
 
 	// attempt the update and check for errors
-	_, err = slf.db.Exec(updQuery)
+	_, err = slf.db.Exec(updQuery, info.args...)
 	if err != nil {
 		return err
 	}
@@ -1010,10 +1702,11 @@ func (slf *SQLiteFlavor) Update(ent interface{}) error {
 	selQuery := "SELECT * FROM " + info.tn + " WHERE " + keyList + " LIMIT 1;"
 	slf.QsLog(selQuery)
 
-	err = slf.db.QueryRowx(selQuery).StructScan(info.ent) //.MapScan(info.resultMap) // SliceScan
+	err = slf.structScan(slf.db.QueryRowx(selQuery), info.ent) //.MapScan(info.resultMap) // SliceScan
 	if err != nil {
 		return err
 	}
+	slf.readTimes(info.ent)
 	info.entValue = reflect.ValueOf(info.ent)
 	return nil
 }
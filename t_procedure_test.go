@@ -0,0 +1,299 @@
+package sqac_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/1414C/sqac"
+	"github.com/jmoiron/sqlx"
+)
+
+// recCall is a statement executed through a recDB, with its arguments.
+type recCall struct {
+	qs   string
+	args []interface{}
+}
+
+// recRowFunc returns the columns and values of the row read by query qs, or
+// no columns if the query yields no rows.
+type recRowFunc func(qs string) ([]string, []driver.Value)
+
+// recRow returns a recRowFunc answering every query with the same row.
+func recRow(cols []string, vals ...driver.Value) recRowFunc {
+	return func(string) ([]string, []driver.Value) { return cols, vals }
+}
+
+// recConnector is a driver.Connector that records the statements executed
+// through it rather than sending them to a db.  Queries return the single
+// row given by row, which allows the statements generated by a flavor to
+// be checked without access to a db of that flavor.
+type recConnector struct {
+	calls *[]recCall
+	row   recRowFunc
+}
+
+func (c recConnector) Connect(context.Context) (driver.Conn, error) { return recConn{c}, nil }
+func (c recConnector) Driver() driver.Driver                        { return recDriver{c} }
+
+type recDriver struct{ c recConnector }
+
+func (d recDriver) Open(string) (driver.Conn, error) { return recConn{d.c}, nil }
+
+type recConn struct{ c recConnector }
+
+func (rc recConn) Prepare(qs string) (driver.Stmt, error) { return recStmt{rc.c, qs}, nil }
+func (rc recConn) Close() error                           { return nil }
+func (rc recConn) Begin() (driver.Tx, error)              { return rc, nil }
+func (rc recConn) Commit() error                          { return nil }
+func (rc recConn) Rollback() error                        { return nil }
+
+// CheckNamedValue accepts all arguments, including sql.Out.
+func (rc recConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+type recStmt struct {
+	c  recConnector
+	qs string
+}
+
+func (s recStmt) Close() error  { return nil }
+func (s recStmt) NumInput() int { return -1 }
+
+func (s recStmt) record(args []driver.NamedValue) {
+	call := recCall{qs: s.qs}
+	for _, a := range args {
+		if a.Name != "" {
+			call.args = append(call.args, sql.Named(a.Name, a.Value))
+			continue
+		}
+		call.args = append(call.args, a.Value)
+	}
+	*s.c.calls = append(*s.c.calls, call)
+}
+
+func (s recStmt) ExecContext(_ context.Context, args []driver.NamedValue) (driver.Result, error) {
+	s.record(args)
+	return driver.RowsAffected(0), nil
+}
+
+func (s recStmt) QueryContext(_ context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	s.record(args)
+	cols, vals := s.c.row(s.qs)
+	return &recRows{cols: cols, vals: vals}, nil
+}
+
+// Exec and Query are not used, as recStmt implements the context variants.
+func (s recStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (s recStmt) Query([]driver.Value) (driver.Rows, error)  { return nil, driver.ErrSkip }
+
+type recRows struct {
+	cols []string
+	vals []driver.Value
+	done bool
+}
+
+func (r *recRows) Columns() []string { return r.cols }
+func (r *recRows) Close() error      { return nil }
+
+func (r *recRows) Next(dest []driver.Value) error {
+	if r.done || len(r.cols) == 0 {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.vals)
+	return nil
+}
+
+// recHandle returns an offline handle of the named flavor whose statements
+// are recorded in calls.  Queries through the handle return the row given
+// by row.
+func recHandle(t *testing.T, flavor string, calls *[]recCall, row recRowFunc) sqac.PublicDB {
+	t.Helper()
+	h, err := sqac.CreateOffline(flavor, false)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	dn := flavor
+	if dn == "sqlite" {
+		dn = "sqlite3"
+	}
+	h.SetDB(sqlx.NewDb(sql.OpenDB(recConnector{calls: calls, row: row}), dn))
+	return h
+}
+
+// TestCallProcedure
+//
+// Call a function returning a result set and a function
+// returning OUT parameters on postgres, and check that
+// the call is refused on SQLite.
+func TestCallProcedure(t *testing.T) {
+
+	type dockIn struct {
+		Lo int `db:"lo"`
+		Hi int `db:"hi"`
+	}
+
+	type dockRow struct {
+		N int `db:"n"`
+	}
+
+	type dockOut struct {
+		Total int `db:"total"`
+		Count int `db:"count"`
+	}
+
+	switch Handle.GetDBDriverName() {
+	case "sqlite3":
+		var rows []dockRow
+		err := Handle.CallProcedure("dock_range", dockIn{Lo: 1, Hi: 3}, &rows)
+		if err == nil {
+			t.Errorf("expected CallProcedure to be refused on SQLite")
+		}
+
+	case "postgres":
+		Handle.ProcessSchemaList([]string{
+			"CREATE OR REPLACE FUNCTION dock_range(lo int, hi int) RETURNS TABLE(n int) AS $$ SELECT generate_series(lo, hi) $$ LANGUAGE SQL;",
+			"CREATE OR REPLACE FUNCTION dock_sum(lo int, hi int, OUT total int, OUT count int) AS $$ SELECT sum(s)::int, count(*)::int FROM generate_series(lo, hi) s $$ LANGUAGE SQL;",
+		})
+		defer Handle.ProcessSchemaList([]string{"DROP FUNCTION dock_range(int, int);", "DROP FUNCTION dock_sum(int, int);"})
+
+		var rows []dockRow
+		err := Handle.CallProcedure("dock_range", dockIn{Lo: 1, Hi: 3}, &rows)
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		if len(rows) != 3 || rows[2].N != 3 {
+			t.Errorf("expected rows 1..3 - got %v", rows)
+		}
+
+		var out dockOut
+		err = Handle.CallProcedure("dock_sum", &dockIn{Lo: 1, Hi: 4}, &out)
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		if out.Total != 10 || out.Count != 4 {
+			t.Errorf("expected total 10 and count 4 - got %v", out)
+		}
+
+	default:
+		t.Skipf("no procedure test for %s", Handle.GetDBDriverName())
+	}
+}
+
+// TestCallProcedureStatements
+//
+// Check the statements issued by CallProcedure on the
+// flavors that have no db in the test run, using handles
+// that record their statements rather than executing them.
+func TestCallProcedureStatements(t *testing.T) {
+
+	type dockIn struct {
+		Lo int `db:"lo"`
+		Hi int `db:"hi"`
+	}
+
+	type dockRow struct {
+		N int `db:"n"`
+	}
+
+	type dockOut struct {
+		Total int `db:"total"`
+		Count int `db:"count"`
+	}
+
+	tests := []struct {
+		flavor   string
+		rangeQs  []string
+		sumQs    []string
+		namedArg bool
+	}{
+		{
+			flavor:  "mysql",
+			rangeQs: []string{"CALL dock_range(?, ?);"},
+			sumQs: []string{
+				"CALL dock_sum(?, ?, @total, @count);",
+				"SELECT @total AS total, @count AS count;",
+			},
+		},
+		{
+			flavor:   "mssql",
+			rangeQs:  []string{"EXEC dock_range @lo = @lo, @hi = @hi;"},
+			sumQs:    []string{"EXEC dock_sum @lo = @lo, @hi = @hi, @total = @total OUTPUT, @count = @count OUTPUT;"},
+			namedArg: true,
+		},
+		{
+			flavor:  "hdb",
+			rangeQs: []string{"CALL dock_range(?, ?);"},
+			sumQs:   []string{"CALL dock_sum(?, ?, ?, ?);"},
+		},
+	}
+
+	statements := func(calls []recCall) []string {
+		qs := make([]string, 0)
+		for _, c := range calls {
+			qs = append(qs, c.qs)
+		}
+		return qs
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flavor, func(t *testing.T) {
+
+			// result set
+			var calls []recCall
+			h := recHandle(t, tt.flavor, &calls, recRow([]string{"n"}, int64(3)))
+			var rows []dockRow
+			err := h.CallProcedure("dock_range", dockIn{Lo: 1, Hi: 3}, &rows)
+			if err != nil {
+				t.Fatalf("%s", err.Error())
+			}
+			if got := statements(calls); !reflect.DeepEqual(got, tt.rangeQs) {
+				t.Errorf("expected %q - got %q", tt.rangeQs, got)
+			}
+			if len(rows) != 1 || rows[0].N != 3 {
+				t.Errorf("expected the recorded row to be read - got %v", rows)
+			}
+			if len(calls) > 0 {
+				args := calls[0].args
+				if len(args) != 2 {
+					t.Fatalf("expected 2 arguments - got %v", args)
+				}
+				if tt.namedArg {
+					if a, ok := args[0].(sql.NamedArg); !ok || a.Name != "lo" {
+						t.Errorf("expected named argument lo - got %v", args[0])
+					}
+				} else if fmt.Sprint(args) != "[1 3]" {
+					t.Errorf("expected arguments 1, 3 - got %v", args)
+				}
+			}
+
+			// OUT parameters
+			calls = nil
+			h = recHandle(t, tt.flavor, &calls, recRow([]string{"total", "count"}, int64(10), int64(4)))
+			var out dockOut
+			err = h.CallProcedure("dock_sum", &dockIn{Lo: 1, Hi: 4}, &out)
+			if err != nil {
+				t.Fatalf("%s", err.Error())
+			}
+			if got := statements(calls); !reflect.DeepEqual(got, tt.sumQs) {
+				t.Errorf("expected %q - got %q", tt.sumQs, got)
+			}
+			if tt.flavor == "mysql" && (out.Total != 10 || out.Count != 4) {
+				t.Errorf("expected total 10 and count 4 - got %v", out)
+			}
+			if tt.flavor == "hdb" && len(calls) > 0 {
+				args := calls[0].args
+				if len(args) != 4 {
+					t.Fatalf("expected 4 arguments - got %v", args)
+				}
+				if _, ok := args[2].(sql.Out); !ok {
+					t.Errorf("expected OUT argument total - got %v", args[2])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package sqac_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Hull is a custom type stored as a single character code
+type Hull int
+
+const (
+	Mono Hull = iota
+	Cat
+	Tri
+)
+
+var hullCodes = []string{"M", "C", "T"}
+
+// Value implements driver.Valuer
+func (h Hull) Value() (driver.Value, error) {
+	if int(h) < 0 || int(h) >= len(hullCodes) {
+		return nil, fmt.Errorf("invalid hull %d", h)
+	}
+	return hullCodes[h], nil
+}
+
+// Scan implements sql.Scanner
+func (h *Hull) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("unable to scan %v into a hull", src)
+	}
+	for i, c := range hullCodes {
+		if c == s {
+			*h = Hull(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown hull code %s", s)
+}
+
+// Yacht holds fields whose types implement driver.Valuer / sql.Scanner
+type Yacht struct {
+	ID    uint64         `db:"id" sqac:"primary_key:inc"`
+	Name  string         `db:"name" sqac:"nullable:false"`
+	Hull  Hull           `db:"hull" sqac:"nullable:false;type:char(1)"`
+	Owner sql.NullString `db:"owner" sqac:"nullable:true"`
+	Crew  sql.NullInt64  `db:"crew" sqac:"nullable:true;type:integer"`
+}
+
+// TestValuer
+//
+// Create, read and update a model holding driver.Valuer
+// field types.
+func TestValuer(t *testing.T) {
+
+	plan, err := Handle.PlanCreateTables(Yacht{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if !strings.Contains(strings.Join(plan, "\n"), "char(1)") {
+		t.Errorf("expected the user-specified column type char(1) in the plan - got %v", plan)
+	}
+
+	err = Handle.DropTables(Yacht{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Yacht{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Yacht{})
+
+	y := Yacht{Name: "Tern", Hull: Cat, Owner: sql.NullString{String: "O'Hara", Valid: true}}
+	err = Handle.Create(&y)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if y.ID == 0 || y.Hull != Cat || y.Owner.String != "O'Hara" || y.Crew.Valid {
+		t.Errorf("unexpected yacht after create - got %v", y)
+	}
+
+	y.Hull = Tri
+	y.Owner = sql.NullString{}
+	y.Crew = sql.NullInt64{Int64: 4, Valid: true}
+	err = Handle.Update(&y)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	r := Yacht{ID: y.ID}
+	err = Handle.GetEntity(&r)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if r.Hull != Tri || r.Owner.Valid || !r.Crew.Valid || r.Crew.Int64 != 4 {
+		t.Errorf("unexpected yacht after update - got %v", r)
+	}
+}
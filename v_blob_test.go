@@ -0,0 +1,76 @@
+package sqac_test
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Manifest holds binary data in []byte fields
+type Manifest struct {
+	ID       uint64  `db:"id" sqac:"primary_key:inc"`
+	Name     string  `db:"name" sqac:"nullable:false"`
+	Scan     []byte  `db:"scan" sqac:"nullable:true"`
+	Checksum *[]byte `db:"checksum" sqac:"nullable:true"`
+}
+
+// TestBlob
+//
+// Create, read and update []byte fields, checking that a nil
+// slice is stored as NULL and an empty slice as an empty value.
+func TestBlob(t *testing.T) {
+
+	err := Handle.DropTables(Manifest{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Manifest{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Manifest{})
+
+	// the column types read back from the db match the model
+	plan, err := Handle.PlanAlterTables(Manifest{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to table manifest - got %v", plan)
+	}
+
+	data := []byte{0x00, 0xff, 0x27, 0x0a, 0x5c}
+	sum := []byte{}
+	m := Manifest{Name: "m1", Scan: data, Checksum: &sum}
+	err = Handle.Create(&m)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	r := Manifest{ID: m.ID}
+	err = Handle.GetEntity(&r)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if !bytes.Equal(r.Scan, data) {
+		t.Errorf("expected scan %v - got %v", data, r.Scan)
+	}
+	if r.Checksum == nil || len(*r.Checksum) != 0 {
+		t.Errorf("expected an empty checksum - got %v", r.Checksum)
+	}
+
+	r.Scan = nil
+	r.Checksum = nil
+	err = Handle.Update(&r)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	u := Manifest{ID: m.ID}
+	err = Handle.GetEntity(&u)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if u.Scan != nil || u.Checksum != nil {
+		t.Errorf("expected NULL scan and checksum - got %v, %v", u.Scan, u.Checksum)
+	}
+}
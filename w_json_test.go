@@ -0,0 +1,114 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac"
+)
+
+// Dims is stored as a json document
+type Dims struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Unit   string `json:"unit"`
+}
+
+// Crate holds json columns wrapping a struct, a map and a slice
+type Crate struct {
+	ID     uint64                        `db:"id" sqac:"primary_key:inc"`
+	Name   string                        `db:"name" sqac:"nullable:false"`
+	Dims   sqac.JSON[Dims]               `db:"dims" sqac:"nullable:true"`
+	Labels sqac.JSON[map[string]string]  `db:"labels" sqac:"nullable:true"`
+	Stops  sqac.JSON[[]string]           `db:"stops" sqac:"nullable:true"`
+	Extra  *sqac.JSON[map[string]string] `db:"extra" sqac:"nullable:true"`
+}
+
+// TestJSON
+//
+// Create, read and select by json path a model holding json
+// columns.
+func TestJSON(t *testing.T) {
+
+	err := Handle.DropTables(Crate{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Crate{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Crate{})
+
+	plan, err := Handle.PlanAlterTables(Crate{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to table crate - got %v", plan)
+	}
+
+	c1 := Crate{
+		Name:   "c1",
+		Dims:   sqac.JSON[Dims]{Data: Dims{Width: 10, Height: 4, Unit: "m"}},
+		Labels: sqac.JSON[map[string]string]{Data: map[string]string{"dest": "O'Hare"}},
+		Stops:  sqac.JSON[[]string]{Data: []string{"halifax", "boston"}},
+	}
+	c2 := Crate{
+		Name: "c2",
+		Dims: sqac.JSON[Dims]{Data: Dims{Width: 20, Height: 4, Unit: "m"}},
+	}
+	for _, c := range []*Crate{&c1, &c2} {
+		err = Handle.Create(c)
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+	}
+
+	r := Crate{ID: c1.ID}
+	err = Handle.GetEntity(&r)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if r.Dims.Data != c1.Dims.Data || r.Labels.Data["dest"] != "O'Hare" ||
+		len(r.Stops.Data) != 2 || r.Stops.Data[1] != "boston" || r.Extra != nil {
+		t.Errorf("unexpected crate read back - got %v", r)
+	}
+
+	// nil maps and slices are stored as NULL
+	r = Crate{ID: c2.ID}
+	err = Handle.GetEntity(&r)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if r.Labels.Data != nil || r.Stops.Data != nil {
+		t.Errorf("expected nil labels and stops - got %v", r)
+	}
+
+	// select on a value in the json document
+	var crates []Crate
+	params := []sqac.GetParam{{FieldName: "Dims", JSONPath: "width", Operand: "=", ParamValue: "20"}}
+	_, err = Handle.GetEntitiesCP(&crates, params, nil)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(crates) != 1 || crates[0].Name != "c2" {
+		t.Errorf("expected to select crate c2 by json path - got %v", crates)
+	}
+
+	crates = nil
+	params = []sqac.GetParam{{FieldName: "Stops", JSONPath: "0", Operand: "=", ParamValue: "halifax"}}
+	_, err = Handle.GetEntitiesCP(&crates, params, nil)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(crates) != 1 || crates[0].Name != "c1" {
+		t.Errorf("expected to select crate c1 by json array index - got %v", crates)
+	}
+
+	params = []sqac.GetParam{{FieldName: "Dims", JSONPath: "width') OR ('1", Operand: "=", ParamValue: "20"}}
+	_, err = Handle.GetEntitiesCP(&crates, params, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid json path") {
+		t.Errorf("expected an invalid json path error - got %v", err)
+	}
+}
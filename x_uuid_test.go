@@ -0,0 +1,86 @@
+package sqac_test
+
+import (
+	"regexp"
+	"testing"
+)
+
+// Voyage is keyed by a uuid
+type Voyage struct {
+	ID   string `db:"id" sqac:"primary_key:uuid"`
+	Name string `db:"name" sqac:"nullable:false"`
+}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// TestUUIDKey
+//
+// Create, read, update and delete entities keyed by a
+// generated or a caller-supplied uuid.
+func TestUUIDKey(t *testing.T) {
+
+	err := Handle.DropTables(Voyage{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Voyage{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Voyage{})
+
+	plan, err := Handle.PlanAlterTables(Voyage{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to table voyage - got %v", plan)
+	}
+
+	// the key is generated when not set by the caller
+	v1 := Voyage{Name: "v1"}
+	err = Handle.Create(&v1)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if !uuidRegexp.MatchString(v1.ID) || v1.Name != "v1" {
+		t.Errorf("expected a generated uuid key - got %v", v1)
+	}
+
+	v2 := Voyage{ID: "0b5e4d0c-8d1a-4f3e-9c7b-2a6f1e0d3c4b", Name: "v2"}
+	err = Handle.Create(&v2)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if v2.ID != "0b5e4d0c-8d1a-4f3e-9c7b-2a6f1e0d3c4b" {
+		t.Errorf("expected the caller-supplied uuid key to be retained - got %v", v2)
+	}
+
+	v1.Name = "v1-renamed"
+	err = Handle.Update(&v1)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	r := Voyage{ID: v1.ID}
+	err = Handle.GetEntity(&r)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if r.Name != "v1-renamed" {
+		t.Errorf("expected the updated voyage - got %v", r)
+	}
+
+	err = Handle.Delete(&v2)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	var voyages []Voyage
+	_, err = Handle.GetEntitiesCP(&voyages, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(voyages) != 1 || voyages[0].ID != v1.ID {
+		t.Errorf("expected only voyage v1 to remain - got %v", voyages)
+	}
+}
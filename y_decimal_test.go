@@ -0,0 +1,82 @@
+package sqac_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac"
+)
+
+// Invoice holds exact decimal amounts
+type Invoice struct {
+	ID       uint64       `db:"id" sqac:"primary_key:inc"`
+	Amount   sqac.Decimal `db:"amount" sqac:"nullable:false;precision:12;scale:2"`
+	Rate     sqac.Decimal `db:"rate" sqac:"nullable:true;precision:9;scale:6"`
+	Discount sqac.Decimal `db:"discount" sqac:"nullable:true"`
+}
+
+// decEqual reports whether decimals a and b hold the same number; the
+// dbs differ in the trailing zeros they return.
+func decEqual(a, b sqac.Decimal) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	ra, ok1 := new(big.Rat).SetString(string(a))
+	rb, ok2 := new(big.Rat).SetString(string(b))
+	return ok1 && ok2 && ra.Cmp(rb) == 0
+}
+
+// TestDecimal
+//
+// Create and read exact decimal values.
+func TestDecimal(t *testing.T) {
+
+	plan, err := Handle.PlanCreateTables(Invoice{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	ddl := strings.Join(plan, "\n")
+	if !strings.Contains(ddl, "(12,2)") || !strings.Contains(ddl, "(9,6)") || !strings.Contains(ddl, "(18,2)") {
+		t.Errorf("expected decimal columns of the tagged precision and scale - got %v", plan)
+	}
+
+	err = Handle.DropTables(Invoice{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Invoice{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Invoice{})
+
+	plan, err = Handle.PlanAlterTables(Invoice{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to table invoice - got %v", plan)
+	}
+
+	inv := Invoice{Amount: "1234567.89", Rate: "0.000125"}
+	err = Handle.Create(&inv)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	r := Invoice{ID: inv.ID}
+	err = Handle.GetEntity(&r)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if !decEqual(r.Amount, "1234567.89") || !decEqual(r.Rate, "0.000125") || r.Discount != "" {
+		t.Errorf("unexpected invoice read back - got %v", r)
+	}
+
+	bad := Invoice{Amount: "12,50"}
+	err = Handle.Create(&bad)
+	if err == nil || !strings.Contains(err.Error(), "is not a decimal number") {
+		t.Errorf("expected an invalid decimal error - got %v", err)
+	}
+}
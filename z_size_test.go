@@ -0,0 +1,107 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac/common"
+)
+
+// TestSizeAndType
+//
+// Create table jetty with sized string columns and
+// user-specified column types, then widen a string column
+// and check that AlterTables detects and applies the size
+// change.
+func TestSizeAndType(t *testing.T) {
+
+	tn := ""
+
+	{
+		type Jetty struct {
+			ID    uint64  `db:"id" sqac:"primary_key:inc;type:integer"`
+			Code  string  `db:"code" sqac:"nullable:false;size:8;index:unique"`
+			Name  string  `db:"name" sqac:"nullable:true;size:40"`
+			Depth int     `db:"depth" sqac:"nullable:true;type:smallint"`
+			Draft float64 `db:"draft" sqac:"nullable:true;type:real"`
+		}
+
+		plan, err := Handle.PlanCreateTables(Jetty{})
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		ddl := strings.ToLower(strings.Join(plan, "\n"))
+		for _, s := range []string{"(8)", "(40)", "smallint", "real"} {
+			if !strings.Contains(ddl, s) {
+				t.Errorf("expected %s in the create plan of table jetty - got %v", s, plan)
+			}
+		}
+
+		err = Handle.DropTables(Jetty{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		err = Handle.CreateTables(Jetty{})
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		tn = common.GetTableName(Jetty{})
+
+		plan, err = Handle.PlanAlterTables(Jetty{})
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+		if len(plan) != 0 {
+			t.Errorf("expected no changes to table %s - got %v", tn, plan)
+		}
+
+		j := Jetty{Code: "J1", Name: "north", Depth: 12}
+		err = Handle.Create(&j)
+		if err != nil {
+			t.Errorf("%s", err.Error())
+		}
+	}
+	defer Handle.DropTables(struct {
+		_ struct{} `sqac:"table:jetty"`
+	}{})
+
+	type Jetty struct {
+		ID    uint64  `db:"id" sqac:"primary_key:inc;type:integer"`
+		Code  string  `db:"code" sqac:"nullable:false;size:16;index:unique"`
+		Name  string  `db:"name" sqac:"nullable:true;size:40"`
+		Depth int     `db:"depth" sqac:"nullable:true;type:smallint"`
+		Draft float64 `db:"draft" sqac:"nullable:true;type:real"`
+	}
+
+	plan, err := Handle.PlanAlterTables(Jetty{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if !strings.Contains(strings.ToLower(strings.Join(plan, "\n")), "(16)") {
+		t.Errorf("expected the size change of column code in the alter plan - got %v", plan)
+	}
+
+	err = Handle.AlterTables(Jetty{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	ti, err := Handle.DescribeTable(tn)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	for _, c := range ti.Columns {
+		if c.Name == "code" && !strings.Contains(c.Type, "16") {
+			t.Errorf("expected column code to be widened to 16 - got %s", c.Type)
+		}
+	}
+
+	var jetties []Jetty
+	_, err = Handle.GetEntitiesCP(&jetties, nil, nil)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(jetties) != 1 || jetties[0].Code != "J1" || jetties[0].Depth != 12 {
+		t.Errorf("expected the jetty to survive the alteration - got %v", jetties)
+	}
+}
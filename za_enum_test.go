@@ -0,0 +1,178 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac/common"
+)
+
+// Shipment status values
+const (
+	Booked    = "booked"
+	InTransit = "in-transit"
+	Delivered = "delivered"
+)
+
+// Shipment limits its status to a set of values
+type Shipment struct {
+	ID     uint64  `db:"id" sqac:"primary_key:inc"`
+	Status string  `db:"status" sqac:"nullable:false;default:booked;size:16;enum:booked|in-transit|delivered"`
+	Hold   *string `db:"hold" sqac:"nullable:true;enum:customs|payment"`
+}
+
+// TestEnum
+//
+// Check that enum values are validated in go, enforced by
+// the db and reported by DescribeTable.
+func TestEnum(t *testing.T) {
+
+	err := Handle.DropTables(Shipment{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Shipment{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Shipment{})
+	tn := common.GetTableName(Shipment{})
+
+	s := Shipment{}
+	err = Handle.Create(&s)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if s.Status != Booked || s.Hold != nil {
+		t.Errorf("expected a booked shipment - got %v", s)
+	}
+
+	hold := "payment"
+	s.Status = InTransit
+	s.Hold = &hold
+	err = Handle.Update(&s)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+
+	// go-side validation
+	s.Status = "lost"
+	err = Handle.Update(&s)
+	if err == nil || !strings.Contains(err.Error(), "is not one of") {
+		t.Errorf("expected an enum validation error - got %v", err)
+	}
+
+	// db-side enforcement
+	_, err = Handle.Exec("INSERT INTO " + tn + " (status) VALUES ('lost')")
+	if err == nil {
+		t.Errorf("expected the db to reject status lost")
+	}
+
+	ti, err := Handle.DescribeTable(tn)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	for _, c := range ti.Columns {
+		switch c.Name {
+		case "status":
+			if strings.Join(c.Enum, "|") != "booked|in-transit|delivered" {
+				t.Errorf("expected the enum values of column status - got %v", c.Enum)
+			}
+		case "hold":
+			if strings.Join(c.Enum, "|") != "customs|payment" {
+				t.Errorf("expected the enum values of column hold - got %v", c.Enum)
+			}
+		}
+	}
+}
+
+// Consignment holds a pair of enum columns whose names share a suffix
+type Consignment struct {
+	ID         uint64 `db:"id" sqac:"primary_key:inc"`
+	Status     string `db:"status" sqac:"nullable:false;default:booked;size:16;enum:booked|delivered"`
+	HoldStatus string `db:"hold_status" sqac:"nullable:false;default:none;size:16;enum:none|customs"`
+}
+
+// ConsignmentV2 adds a value to the enum of column status of table
+// consignment
+type ConsignmentV2 struct {
+	ID         uint64 `db:"id" sqac:"primary_key:inc"`
+	Status     string `db:"status" sqac:"nullable:false;default:booked;size:16;enum:booked|delivered|lost"`
+	HoldStatus string `db:"hold_status" sqac:"nullable:false;default:none;size:16;enum:none|customs"`
+}
+
+func (c ConsignmentV2) TableName() string {
+	return "consignment"
+}
+
+// TestAlterEnum
+//
+// Check that the enum values of each column are read back
+// on their own, and that a change to the values of an enum
+// is applied to the db by AlterTables.
+func TestAlterEnum(t *testing.T) {
+
+	err := Handle.DropTables(Consignment{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Consignment{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Consignment{})
+
+	enums := func() map[string]string {
+		ti, err := Handle.DescribeTable("consignment")
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		m := make(map[string]string)
+		for _, c := range ti.Columns {
+			m[c.Name] = strings.Join(c.Enum, "|")
+		}
+		return m
+	}
+	e := enums()
+	if e["status"] != "booked|delivered" || e["hold_status"] != "none|customs" {
+		t.Errorf("unexpected enum values of table consignment - got %v", e)
+	}
+
+	plan, err := Handle.PlanAlterTables(Consignment{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to table consignment - got %v", plan)
+	}
+
+	plan, err = Handle.PlanAlterTables(ConsignmentV2{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) == 0 {
+		t.Errorf("expected the enum of column status to be re-created - got no changes")
+	}
+
+	err = Handle.AlterTables(ConsignmentV2{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	c := ConsignmentV2{Status: "lost"}
+	err = Handle.Create(&c)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	e = enums()
+	if e["status"] != "booked|delivered|lost" || e["hold_status"] != "none|customs" {
+		t.Errorf("unexpected enum values of table consignment after the alter - got %v", e)
+	}
+
+	plan, err = Handle.PlanAlterTables(ConsignmentV2{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to table consignment after the alter - got %v", plan)
+	}
+}
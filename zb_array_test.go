@@ -0,0 +1,197 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac"
+)
+
+// Cask holds array columns
+type Cask struct {
+	ID    uint64              `db:"id" sqac:"primary_key:inc"`
+	Name  string              `db:"name" sqac:"nullable:false"`
+	Tags  sqac.Array[string]  `db:"tags" sqac:"nullable:true"`
+	Sizes sqac.Array[int64]   `db:"sizes" sqac:"nullable:true"`
+	Temps sqac.Array[float64] `db:"temps" sqac:"nullable:true"`
+	Hoops sqac.Array[int]     `db:"hoops" sqac:"nullable:true"`
+}
+
+// TestArray
+//
+// Create, read and filter array columns; native arrays on
+// postgres and json documents on the other dbs.
+func TestArray(t *testing.T) {
+
+	err := Handle.DropTables(Cask{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Cask{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Cask{})
+
+	plan, err := Handle.PlanAlterTables(Cask{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to table cask - got %v", plan)
+	}
+
+	casks := []Cask{
+		{Name: "c1", Tags: sqac.Array[string]{"oak", "sherry"}, Sizes: sqac.Array[int64]{50, 200}, Temps: sqac.Array[float64]{12.5}},
+		{Name: "c2", Tags: sqac.Array[string]{"oak", "port", "it's"}, Sizes: sqac.Array[int64]{200}},
+		{Name: "c3"},
+	}
+	for i := range casks {
+		err = Handle.Create(&casks[i])
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+	}
+
+	r := Cask{ID: casks[1].ID}
+	err = Handle.GetEntity(&r)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(r.Tags) != 3 || r.Tags[2] != "it's" || len(r.Sizes) != 1 || r.Sizes[0] != 200 || r.Temps != nil {
+		t.Errorf("unexpected cask read back - got %v", r)
+	}
+
+	var got []Cask
+	params := []sqac.GetParam{{FieldName: "Tags", Operand: "ANY", ParamValue: "sherry"}}
+	_, err = Handle.GetEntitiesCP(&got, params, nil)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(got) != 1 || got[0].Name != "c1" {
+		t.Errorf("expected cask c1 to hold tag sherry - got %v", got)
+	}
+
+	got = nil
+	params = []sqac.GetParam{{FieldName: "Tags", Operand: "@>", ParamValue: sqac.Array[string]{"port", "oak"}}}
+	_, err = Handle.GetEntitiesCP(&got, params, nil)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(got) != 1 || got[0].Name != "c2" {
+		t.Errorf("expected cask c2 to hold tags port and oak - got %v", got)
+	}
+
+	got = nil
+	params = []sqac.GetParam{{FieldName: "Sizes", Operand: "ANY", ParamValue: int64(200)}}
+	_, err = Handle.GetEntitiesCP(&got, params, nil)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(got) != 2 {
+		t.Errorf("expected casks c1 and c2 to hold size 200 - got %v", got)
+	}
+}
+
+// Keg holds plain slices, which are stored like sqac.Array
+type Keg struct {
+	ID    uint64   `db:"id" sqac:"primary_key:inc"`
+	Name  string   `db:"name" sqac:"nullable:false"`
+	Tags  []string `db:"tags" sqac:"nullable:true"`
+	Sizes []int64  `db:"sizes" sqac:"nullable:true"`
+}
+
+// TestArrayPlainSlice
+//
+// Create, read and filter the plain slice columns of table
+// keg, check that a slice of an unsupported element type is
+// rejected, and that Array[int] is read back.
+func TestArrayPlainSlice(t *testing.T) {
+
+	err := Handle.DropTables(Keg{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Keg{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Keg{})
+
+	plan, err := Handle.PlanAlterTables(Keg{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to table keg - got %v", plan)
+	}
+
+	kegs := []Keg{
+		{Name: "k1", Tags: []string{"oak", "sherry"}, Sizes: []int64{50}},
+		{Name: "k2"},
+	}
+	for i := range kegs {
+		err = Handle.Create(&kegs[i])
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+	}
+	if len(kegs[0].Tags) != 2 || kegs[0].Tags[1] != "sherry" || len(kegs[0].Sizes) != 1 || kegs[0].Sizes[0] != 50 {
+		t.Errorf("unexpected keg read back on create - got %v", kegs[0])
+	}
+
+	k := Keg{ID: kegs[1].ID}
+	err = Handle.GetEntity(&k)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if k.Name != "k2" || k.Tags != nil || k.Sizes != nil {
+		t.Errorf("expected keg k2 without tags and sizes - got %v", k)
+	}
+
+	var got []Keg
+	params := []sqac.GetParam{{FieldName: "Tags", Operand: "ANY", ParamValue: "oak"}}
+	_, err = Handle.GetEntitiesCP(&got, params, nil)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(got) != 1 || got[0].Name != "k1" || len(got[0].Tags) != 2 {
+		t.Errorf("expected keg k1 to hold tag oak - got %v", got)
+	}
+
+	ddl, err := sqac.ExportDDL("postgres", Keg{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if !strings.Contains(ddl, "tags text[]") || !strings.Contains(ddl, "sizes bigint[]") {
+		t.Errorf("expected native array columns tags and sizes on postgres - got:\n%s", ddl)
+	}
+
+	type Vat struct {
+		ID    uint64   `db:"id" sqac:"primary_key:inc"`
+		Stops []uint16 `db:"stops" sqac:"nullable:true"`
+	}
+	err = Handle.Create(&Vat{Stops: []uint16{1}})
+	if err == nil || !strings.Contains(err.Error(), "Stops") {
+		t.Errorf("expected an error for field Stops of Vat - got %v", err)
+	}
+
+	err = Handle.DropTables(Cask{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Cask{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Cask{})
+
+	c := Cask{Name: "c4", Hoops: sqac.Array[int]{6, 8}}
+	err = Handle.Create(&c)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(c.Hoops) != 2 || c.Hoops[0] != 6 || c.Hoops[1] != 8 {
+		t.Errorf("expected hoops 6 and 8 to be read back - got %v", c.Hoops)
+	}
+}
@@ -0,0 +1,170 @@
+package sqac_test
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/1414C/sqac"
+)
+
+// Sailing holds times on either side of the DST changes
+type Sailing struct {
+	ID      uint64     `db:"id" sqac:"primary_key:inc"`
+	Name    string     `db:"name" sqac:"nullable:false"`
+	Departs time.Time  `db:"departs" sqac:"nullable:false"`
+	Arrives *time.Time `db:"arrives" sqac:"nullable:true"`
+}
+
+// TestTimePolicy
+//
+// Write times spanning the 2024 DST changes of New York
+// and read them back under the different time policies.
+func TestTimePolicy(t *testing.T) {
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	err = Handle.DropTables(Sailing{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Sailing{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Sailing{})
+	defer Handle.SetTimePolicy(Handle.GetTimePolicy())
+
+	// departure times given in New York time, and the zone expected when
+	// reading them back in New York time
+	sailings := []struct {
+		departs time.Time
+		zone    string
+	}{
+		{time.Date(2024, 3, 10, 1, 30, 0, 0, ny), "EST"},                          // before spring-forward
+		{time.Date(2024, 3, 10, 3, 30, 0, 0, ny), "EDT"},                          // after spring-forward
+		{time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC).In(ny), "EDT"},             // first 01:30
+		{time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC).In(ny), "EST"},             // second 01:30
+		{time.Date(2024, 7, 1, 12, 0, 0, 0, time.FixedZone("CEST", 7200)), "EDT"}, // other zone
+	}
+
+	// the default policy stores and reads times in UTC
+	Handle.SetTimePolicy(sqac.TimePolicy{})
+	ids := make([]uint64, 0)
+	for i, sc := range sailings {
+		arr := sc.departs.Add(90 * time.Minute)
+		s := Sailing{Name: "s", Departs: sc.departs, Arrives: &arr}
+		err = Handle.Create(&s)
+		if err != nil {
+			t.Fatalf("%s", err.Error())
+		}
+		if !s.Departs.Equal(sc.departs) || s.Departs.Location() != time.UTC {
+			t.Errorf("sailing %d: expected departure %v in UTC - got %v", i, sc.departs.UTC(), s.Departs)
+		}
+		if s.Arrives == nil || !s.Arrives.Equal(arr) || s.Arrives.Location() != time.UTC {
+			t.Errorf("sailing %d: expected arrival %v in UTC - got %v", i, arr.UTC(), s.Arrives)
+		}
+		ids = append(ids, s.ID)
+	}
+
+	// read in New York time
+	Handle.SetTimePolicy(sqac.TimePolicy{Location: ny})
+	var got []Sailing
+	_, err = Handle.GetEntitiesCP(&got, nil, nil)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(got) != len(sailings) {
+		t.Fatalf("expected %d sailings - got %d", len(sailings), len(got))
+	}
+	for i, s := range got {
+		zone, _ := s.Departs.Zone()
+		if !s.Departs.Equal(sailings[i].departs) || zone != sailings[i].zone {
+			t.Errorf("sailing %d: expected departure %v %s - got %v", i, sailings[i].departs, sailings[i].zone, s.Departs)
+		}
+		if s.Arrives == nil || s.Arrives.Location() != ny {
+			t.Errorf("sailing %d: expected arrival in New York time - got %v", i, s.Arrives)
+		}
+	}
+
+	r := Sailing{ID: ids[1]}
+	err = Handle.GetEntity(&r)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if r.Departs.Location() != ny || r.Departs.Hour() != 3 {
+		t.Errorf("expected departure 03:30 EDT - got %v", r.Departs)
+	}
+
+	// stored as-is, the dbs without zone-aware time types retain the
+	// wall-clock time
+	Handle.SetTimePolicy(sqac.TimePolicy{Store: sqac.TimeStoreAsIs})
+	s := Sailing{Name: "as-is", Departs: time.Date(2024, 3, 10, 1, 30, 0, 0, ny)}
+	err = Handle.Create(&s)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if Handle.GetDBDriverName() == "postgres" {
+		if !s.Departs.Equal(time.Date(2024, 3, 10, 6, 30, 0, 0, time.UTC)) {
+			t.Errorf("expected the departure instant to be retained - got %v", s.Departs)
+		}
+	} else if !s.Departs.Equal(time.Date(2024, 3, 10, 1, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected the departure wall-clock time to be retained - got %v", s.Departs)
+	}
+}
+
+// TestTimePolicyDriverLocation
+//
+// Read the times of a db without a zone-aware time type via
+// a driver set to hand back times in New York, and check
+// that the instants stored are retained.
+func TestTimePolicyDriverLocation(t *testing.T) {
+
+	var h sqac.PublicDB
+	switch Handle.GetDBDriverName() {
+	case "sqlite3":
+		h = sqac.Create("sqlite", false, false, "file:testdb.sqlite?_loc=America/New_York")
+	case "mysql":
+		h = sqac.Create("mysql", false, false, "godev:gogogo123@tcp(localhost:3306)/sqactst?charset=utf8&parseTime=True&loc=America%2FNew_York")
+	default:
+		t.Skipf("driver %s hands back times in UTC", Handle.GetDBDriverName())
+	}
+	defer h.Close()
+
+	err := h.DropTables(Sailing{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = h.CreateTables(Sailing{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer h.DropTables(Sailing{})
+
+	departs := time.Date(2024, 3, 10, 6, 30, 0, 0, time.UTC)
+	s := Sailing{Name: "s", Departs: departs}
+	err = h.Create(&s)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if !s.Departs.Equal(departs) || s.Departs.Location() != time.UTC {
+		t.Errorf("expected departure %v - got %v", departs, s.Departs)
+	}
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	h.SetTimePolicy(sqac.TimePolicy{Store: sqac.TimeStoreAsIs, Location: ny})
+	s = Sailing{Name: "as-is", Departs: time.Date(2024, 3, 10, 1, 30, 0, 0, ny)}
+	err = h.Create(&s)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if !s.Departs.Equal(departs) || s.Departs.Location() != ny {
+		t.Errorf("expected departure %v - got %v", departs.In(ny), s.Departs)
+	}
+}
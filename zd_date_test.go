@@ -0,0 +1,105 @@
+package sqac_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/1414C/sqac"
+)
+
+// Slot holds date-only and time-only columns
+type Slot struct {
+	ID      uint64          `db:"id" sqac:"primary_key:inc"`
+	Day     sqac.Date       `db:"day" sqac:"nullable:false;index:non-unique"`
+	Opens   sqac.TimeOfDay  `db:"opens" sqac:"nullable:false"`
+	Closes  *sqac.TimeOfDay `db:"closes" sqac:"nullable:true"`
+	Holiday *sqac.Date      `db:"holiday" sqac:"nullable:true"`
+}
+
+// TestDateAndTimeOfDay
+//
+// Create date and time columns and read their values back
+// unaffected by the time policy of the handle.
+func TestDateAndTimeOfDay(t *testing.T) {
+
+	plan, err := Handle.PlanCreateTables(Slot{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	ddl := strings.ToLower(strings.Join(plan, "\n"))
+	if !strings.Contains(ddl, "date not null") || !strings.Contains(ddl, "time not null") {
+		t.Errorf("expected date and time columns in the create plan of table slot - got %v", plan)
+	}
+
+	err = Handle.DropTables(Slot{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	err = Handle.CreateTables(Slot{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.DropTables(Slot{})
+
+	plan, err = Handle.PlanAlterTables(Slot{})
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if len(plan) != 0 {
+		t.Errorf("expected no changes to table slot - got %v", plan)
+	}
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	defer Handle.SetTimePolicy(Handle.GetTimePolicy())
+	Handle.SetTimePolicy(sqac.TimePolicy{Location: ny})
+
+	// 02:30 does not exist in New York on the day of the spring-forward
+	day := sqac.Date{Year: 2024, Month: time.March, Day: 10}
+	opens := sqac.TimeOfDay{Hour: 2, Minute: 30}
+	closes := sqac.TimeOfDayOf(time.Date(2024, 3, 10, 23, 45, 10, 0, ny))
+	s := Slot{Day: day, Opens: opens, Closes: &closes}
+	err = Handle.Create(&s)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if s.Day != day || s.Opens != opens || s.Closes == nil || *s.Closes != closes || s.Holiday != nil {
+		t.Errorf("unexpected slot read back - got %v", s)
+	}
+
+	r := Slot{ID: s.ID}
+	err = Handle.GetEntity(&r)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if r.Day != day || r.Opens != opens || r.Closes == nil || r.Closes.String() != "23:45:10" {
+		t.Errorf("unexpected slot read back - got %v", r)
+	}
+
+	holiday := sqac.DateOf(time.Date(2024, 12, 25, 23, 0, 0, 0, ny))
+	r.Holiday = &holiday
+	err = Handle.Update(&r)
+	if err != nil {
+		t.Errorf("%s", err.Error())
+	}
+	if r.Holiday == nil || r.Holiday.String() != "2024-12-25" {
+		t.Errorf("expected holiday 2024-12-25 - got %v", r.Holiday)
+	}
+
+	var got []Slot
+	params := []sqac.GetParam{{FieldName: "Day", Operand: "=", ParamValue: day}}
+	_, err = Handle.GetEntitiesCP(&got, params, nil)
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(got) != 1 || got[0].ID != s.ID {
+		t.Errorf("expected the slot of %s - got %v", day, got)
+	}
+
+	if Handle.TimeToFormattedString(day) != "2024-03-10" || Handle.TimeToFormattedString(opens) != "02:30:00" {
+		t.Errorf("unexpected formatting of date %s and time %s", day, opens)
+	}
+}
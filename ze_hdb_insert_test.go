@@ -0,0 +1,153 @@
+package sqac_test
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/1414C/sqac"
+)
+
+// TestExportHDBInsertSP
+//
+// Check that the HDB DDL of a table with an auto-incrementing
+// key includes the insert procedure used by Create, drawing
+// the key from the sequence of the table and defaulting the
+// omitted parameters to the defaults of their columns.
+func TestExportHDBInsertSP(t *testing.T) {
+
+	type Skiff struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		Name   string `db:"name" sqac:"nullable:false;default:none"`
+		Length int    `db:"length"`
+	}
+
+	type Oar struct {
+		Code string `db:"code" sqac:"primary_key:"`
+	}
+
+	script, err := sqac.ExportDDL("hdb", Skiff{}, Oar{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+
+	for _, want := range []string{
+		"CREATE OR REPLACE PROCEDURE INS_SKIFF (IN name nvarchar(255) DEFAULT 'none', IN length int DEFAULT NULL, OUT id bigint)",
+		"SELECT SEQ_SKIFF_ID.NEXTVAL INTO id FROM DUMMY;",
+		"INSERT INTO skiff (id, name, length) VALUES (:id, :name, :length);",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("expected '%s' in the exported DDL - got:\n%s", want, script)
+		}
+	}
+	if strings.Contains(script, "INS_OAR") {
+		t.Errorf("expected no insert procedure for table oar - got:\n%s", script)
+	}
+}
+
+// TestHDBCreateInsertSP
+//
+// Create rows of an HDB table with an auto-incrementing key
+// via the insert procedure of the table, and via a plain
+// insert if the table has no insert procedure.  Check that
+// the catalog is consulted for the procedure only once, and
+// not at all once the handle has created the procedure.
+func TestHDBCreateInsertSP(t *testing.T) {
+
+	type Skiff struct {
+		ID     uint64 `db:"id" sqac:"primary_key:inc"`
+		Name   string `db:"name" sqac:"nullable:false;default:none"`
+		Length int    `db:"length"`
+	}
+
+	row := func(procs int64) recRowFunc {
+		return func(qs string) ([]string, []driver.Value) {
+			switch {
+			case strings.Contains(qs, "Sys.Procedures"):
+				return []string{"n"}, []driver.Value{procs}
+			case strings.Contains(qs, "NEXTVAL"):
+				return []string{"nextval"}, []driver.Value{int64(41)}
+			case strings.HasPrefix(qs, "SELECT * FROM skiff"):
+				return []string{"id", "name", "length"}, []driver.Value{int64(41), "punt", int64(12)}
+			}
+			return nil, nil
+		}
+	}
+
+	tests := []struct {
+		name  string
+		procs int64
+		want  []string
+	}{
+		{
+			name:  "fallback",
+			procs: 0,
+			want: []string{
+				"SELECT COUNT(*) FROM Sys.Procedures",
+				"SELECT SEQ_SKIFF_ID.NEXTVAL FROM DUMMY;",
+				"INSERT INTO skiff (id, name, length) VALUES (41, 'punt', 12);",
+				"SELECT * FROM skiff WHERE id = 41;",
+				"SELECT SEQ_SKIFF_ID.NEXTVAL FROM DUMMY;",
+				"INSERT INTO skiff (id, name, length) VALUES (41, 'punt', 12);",
+				"SELECT * FROM skiff WHERE id = 41;",
+			},
+		},
+		{
+			// the recording handle leaves the OUT parameter untouched
+			name:  "procedure",
+			procs: 1,
+			want: []string{
+				"SELECT COUNT(*) FROM Sys.Procedures",
+				"CALL INS_SKIFF(name => 'punt', length => 12, id => ?);",
+				"SELECT * FROM skiff WHERE id = 0;",
+				"CALL INS_SKIFF(name => 'punt', length => 12, id => ?);",
+				"SELECT * FROM skiff WHERE id = 0;",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []recCall
+			h := recHandle(t, "hdb", &calls, row(tt.procs))
+			for i := 0; i < 2; i++ {
+				s := Skiff{Name: "punt", Length: 12}
+				err := h.Create(&s)
+				if err != nil {
+					t.Fatalf("%s", err.Error())
+				}
+				if s.ID != 41 || s.Name != "punt" {
+					t.Errorf("expected the created row to be read back - got %v", s)
+				}
+			}
+			got := make([]string, 0)
+			for _, c := range calls {
+				got = append(got, c.qs)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %q - got %q", tt.want, got)
+			}
+			for i := range got {
+				if !strings.HasPrefix(got[i], tt.want[i]) {
+					t.Errorf("expected %q - got %q", tt.want[i], got[i])
+				}
+			}
+		})
+	}
+
+	// the procedure created by the handle is used without a catalog lookup
+	var calls []recCall
+	h := recHandle(t, "hdb", &calls, row(0))
+	err := h.CreateTables(Skiff{})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	calls = nil
+	err = h.Create(&Skiff{Name: "punt", Length: 12})
+	if err != nil {
+		t.Fatalf("%s", err.Error())
+	}
+	if len(calls) == 0 || !strings.HasPrefix(calls[0].qs, "CALL INS_SKIFF(") {
+		t.Errorf("expected the insert procedure to be called - got %v", calls)
+	}
+}